@@ -0,0 +1,268 @@
+package form3
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// accountType is the JSON:API type of an account.
+const accountType string = "accounts"
+
+// accountScheme contains the defaults and the rules an account must follow in a given country.
+//
+// More details available in: https://www.api-docs.form3.tech/api/schemes/fps-direct/accounts/accounts/account-attributes-per-country
+type accountScheme struct {
+	country        string         // ISO 3166-1 alpha-2 country code.
+	baseCurrency   string         // ISO 4217 currency code used by default.
+	bankIDCode     string         // Identifies the type of bank ID being used.
+	bankIDRequired bool           // If the bank ID must be provided.
+	bankID         *regexp.Regexp // Format that the bank ID must follow.
+	bicRequired    bool           // If the BIC must be provided.
+	accountNumber  *regexp.Regexp // Format that the account number must follow.
+}
+
+var (
+	bicFormat = regexp.MustCompile(`^([A-Z]{6}[A-Z0-9]{2}|[A-Z]{6}[A-Z0-9]{5})$`)
+
+	ukScheme           = accountScheme{country: "GB", baseCurrency: "GBP", bankIDCode: "GBDSC", bankIDRequired: true, bankID: regexp.MustCompile(`^[0-9]{6}$`), bicRequired: true, accountNumber: regexp.MustCompile(`^[0-9]{8}$`)}
+	australianScheme   = accountScheme{country: "AU", baseCurrency: "AUD", bankIDCode: "AUBSB", bankID: regexp.MustCompile(`^[0-9]{6}$`), bicRequired: true, accountNumber: regexp.MustCompile(`^[0-9]{6,10}$`)}
+	belgianScheme      = accountScheme{country: "BE", baseCurrency: "EUR", bankIDCode: "BE", bankIDRequired: true, bankID: regexp.MustCompile(`^[0-9]{3}$`), accountNumber: regexp.MustCompile(`^[0-9]{7}$`)}
+	canadianScheme     = accountScheme{country: "CA", baseCurrency: "CAD", bankIDCode: "CACPA", bankID: regexp.MustCompile(`^0[0-9]{8}$`), bicRequired: true, accountNumber: regexp.MustCompile(`^[0-9]{7,12}$`)}
+	frenchScheme       = accountScheme{country: "FR", baseCurrency: "EUR", bankIDCode: "FR", bankIDRequired: true, bankID: regexp.MustCompile(`^[0-9]{10}$`), accountNumber: regexp.MustCompile(`^[0-9]{10}$`)}
+	germanScheme       = accountScheme{country: "DE", baseCurrency: "EUR", bankIDCode: "DEBLZ", bankIDRequired: true, bankID: regexp.MustCompile(`^[0-9]{8}$`), accountNumber: regexp.MustCompile(`^[0-9]{7}$`)}
+	italianScheme      = accountScheme{country: "IT", baseCurrency: "EUR", bankIDCode: "ITNCC", bankIDRequired: true, bankID: regexp.MustCompile(`^[0-9A-Z]{10,11}$`), accountNumber: regexp.MustCompile(`^[0-9]{12}$`)}
+	dutchScheme        = accountScheme{country: "NL", baseCurrency: "EUR", bicRequired: true, accountNumber: regexp.MustCompile(`^[0-9]{10}$`)}
+	spanishScheme      = accountScheme{country: "ES", baseCurrency: "EUR", bankIDCode: "ESNCC", bankIDRequired: true, bankID: regexp.MustCompile(`^[0-9]{8,9}$`), accountNumber: regexp.MustCompile(`^[0-9]{10}$`)}
+	unitedStatesScheme = accountScheme{country: "US", baseCurrency: "USD", bankIDCode: "USABA", bankIDRequired: true, bankID: regexp.MustCompile(`^[0-9]{9}$`), bicRequired: true, accountNumber: regexp.MustCompile(`^[0-9]{6,17}$`)}
+)
+
+// AccountBuilder helps building an account that follows the rules of a country.
+//
+// The account is only validated when it is built.
+type AccountBuilder struct {
+	scheme  accountScheme
+	account *Account
+}
+
+// NewUKAccount starts building an account in the United Kingdom.
+func NewUKAccount(organisationID string) *AccountBuilder {
+	return newAccountBuilder(organisationID, ukScheme)
+}
+
+// NewAustralianAccount starts building an account in Australia.
+func NewAustralianAccount(organisationID string) *AccountBuilder {
+	return newAccountBuilder(organisationID, australianScheme)
+}
+
+// NewBelgianAccount starts building an account in Belgium.
+func NewBelgianAccount(organisationID string) *AccountBuilder {
+	return newAccountBuilder(organisationID, belgianScheme)
+}
+
+// NewCanadianAccount starts building an account in Canada.
+func NewCanadianAccount(organisationID string) *AccountBuilder {
+	return newAccountBuilder(organisationID, canadianScheme)
+}
+
+// NewFrenchAccount starts building an account in France.
+func NewFrenchAccount(organisationID string) *AccountBuilder {
+	return newAccountBuilder(organisationID, frenchScheme)
+}
+
+// NewGermanAccount starts building an account in Germany.
+func NewGermanAccount(organisationID string) *AccountBuilder {
+	return newAccountBuilder(organisationID, germanScheme)
+}
+
+// NewItalianAccount starts building an account in Italy.
+func NewItalianAccount(organisationID string) *AccountBuilder {
+	return newAccountBuilder(organisationID, italianScheme)
+}
+
+// NewDutchAccount starts building an account in the Netherlands.
+func NewDutchAccount(organisationID string) *AccountBuilder {
+	return newAccountBuilder(organisationID, dutchScheme)
+}
+
+// NewSpanishAccount starts building an account in Spain.
+func NewSpanishAccount(organisationID string) *AccountBuilder {
+	return newAccountBuilder(organisationID, spanishScheme)
+}
+
+// NewUSAccount starts building an account in the United States.
+func NewUSAccount(organisationID string) *AccountBuilder {
+	return newAccountBuilder(organisationID, unitedStatesScheme)
+}
+
+func newAccountBuilder(organisationID string, scheme accountScheme) *AccountBuilder {
+	return &AccountBuilder{
+		scheme: scheme,
+		account: &Account{
+			Data: &AccountData{
+				OrganisationID: organisationID,
+				Type:           accountType,
+				Attributes: &AccountAttributes{
+					Country:      scheme.country,
+					BaseCurrency: scheme.baseCurrency,
+					BankIDCode:   scheme.bankIDCode,
+				},
+			},
+		},
+	}
+}
+
+// WithID sets the account ID. A random UUID is used if not set.
+func (b *AccountBuilder) WithID(id string) *AccountBuilder {
+	b.account.Data.ID = id
+
+	return b
+}
+
+// WithBankID sets the bank ID, for example a sort code or a BLZ.
+func (b *AccountBuilder) WithBankID(bankID string) *AccountBuilder {
+	b.account.Data.Attributes.BankID = bankID
+
+	return b
+}
+
+// WithSortCode sets the bank ID of UK accounts.
+func (b *AccountBuilder) WithSortCode(sortCode string) *AccountBuilder {
+	return b.WithBankID(sortCode)
+}
+
+// WithBic sets the SWIFT BIC.
+func (b *AccountBuilder) WithBic(bic string) *AccountBuilder {
+	b.account.Data.Attributes.Bic = bic
+
+	return b
+}
+
+// WithAccountNumber sets the account number. It is generated by FORM3 if not set.
+func (b *AccountBuilder) WithAccountNumber(accountNumber string) *AccountBuilder {
+	b.account.Data.Attributes.AccountNumber = accountNumber
+
+	return b
+}
+
+// WithIban sets the IBAN. It is generated by FORM3 if not set.
+func (b *AccountBuilder) WithIban(iban string) *AccountBuilder {
+	b.account.Data.Attributes.Iban = iban
+
+	return b
+}
+
+// WithBaseCurrency overrides the currency used by default in the country.
+func (b *AccountBuilder) WithBaseCurrency(baseCurrency string) *AccountBuilder {
+	b.account.Data.Attributes.BaseCurrency = baseCurrency
+
+	return b
+}
+
+// WithNames sets the names of the account holder.
+func (b *AccountBuilder) WithNames(names ...string) *AccountBuilder {
+	b.account.Data.Attributes.Name = names
+
+	return b
+}
+
+// WithAlternativeNames sets the alternative names of the account holder.
+func (b *AccountBuilder) WithAlternativeNames(alternativeNames ...string) *AccountBuilder {
+	b.account.Data.Attributes.AlternativeNames = alternativeNames
+
+	return b
+}
+
+// WithAccountClassification sets if the account is "Personal" or "Business".
+func (b *AccountBuilder) WithAccountClassification(accountClassification string) *AccountBuilder {
+	b.account.Data.Attributes.AccountClassification = accountClassification
+
+	return b
+}
+
+// WithSecondaryIdentification sets the secondary identification, for example a building society roll number.
+func (b *AccountBuilder) WithSecondaryIdentification(secondaryIdentification string) *AccountBuilder {
+	b.account.Data.Attributes.SecondaryIdentification = secondaryIdentification
+
+	return b
+}
+
+// WithJointAccount sets if the account is held by more than one person.
+func (b *AccountBuilder) WithJointAccount(jointAccount bool) *AccountBuilder {
+	b.account.Data.Attributes.JointAccount = jointAccount
+
+	return b
+}
+
+// WithAccountMatchingOptOut sets if the account holder opted out of account matching.
+func (b *AccountBuilder) WithAccountMatchingOptOut(accountMatchingOptOut bool) *AccountBuilder {
+	b.account.Data.Attributes.AccountMatchingOptOut = accountMatchingOptOut
+
+	return b
+}
+
+// WithSwitched sets if the account has been switched using the Current Account Switch Service.
+func (b *AccountBuilder) WithSwitched(switched bool) *AccountBuilder {
+	b.account.Data.Attributes.Switched = switched
+
+	return b
+}
+
+// Build validates the account and returns a copy of it.
+//
+// A random UUID is generated for every account built if no ID was set.
+// A ValidationError is returned if any required field is missing or does not follow the country rules.
+func (b *AccountBuilder) Build() (*Account, error) {
+	data := b.account.Data
+	attributes := data.Attributes
+
+	if data.OrganisationID == "" {
+		return nil, ValidationError{Field: "organisation_id", Message: "is required"}
+	}
+
+	if len(attributes.Name) < 1 || len(attributes.Name) > 4 {
+		return nil, ValidationError{Field: "name", Message: "must have between 1 and 4 names"}
+	}
+
+	if attributes.BankID == "" && b.scheme.bankIDRequired {
+		return nil, ValidationError{Field: "bank_id", Message: fmt.Sprintf("is required in %s", b.scheme.country)}
+	}
+
+	if attributes.BankID != "" && b.scheme.bankID == nil {
+		return nil, ValidationError{Field: "bank_id", Message: fmt.Sprintf("is not supported in %s", b.scheme.country)}
+	}
+
+	if attributes.BankID != "" && !b.scheme.bankID.MatchString(attributes.BankID) {
+		return nil, ValidationError{Field: "bank_id", Message: fmt.Sprintf("must match %s", b.scheme.bankID)}
+	}
+
+	if attributes.Bic == "" && b.scheme.bicRequired {
+		return nil, ValidationError{Field: "bic", Message: fmt.Sprintf("is required in %s", b.scheme.country)}
+	}
+
+	if attributes.Bic != "" && !bicFormat.MatchString(attributes.Bic) {
+		return nil, ValidationError{Field: "bic", Message: fmt.Sprintf("must match %s", bicFormat)}
+	}
+
+	if attributes.AccountNumber != "" && !b.scheme.accountNumber.MatchString(attributes.AccountNumber) {
+		return nil, ValidationError{Field: "account_number", Message: fmt.Sprintf("must match %s", b.scheme.accountNumber)}
+	}
+
+	// The account is a deep copy, so that the builder can be reused as a template without changing built accounts
+	builtAttributes := *attributes
+	builtAttributes.Name = append([]string(nil), attributes.Name...)
+	builtAttributes.AlternativeNames = append([]string(nil), attributes.AlternativeNames...)
+	builtData := *data
+	builtData.Attributes = &builtAttributes
+
+	if builtData.ID == "" {
+		id, error := newUuid()
+
+		if error != nil {
+			return nil, OperationError{Message: error.Error()}
+		}
+
+		builtData.ID = id
+	}
+
+	return &Account{Data: &builtData}, nil
+}
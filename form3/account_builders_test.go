@@ -0,0 +1,149 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountBuilder_Build(t *testing.T) {
+	t.Run("should build an UK account with scheme defaults and a generated ID", func(t *testing.T) {
+		t.Parallel()
+
+		account, error := form3.NewUKAccount("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").
+			WithSortCode("400300").
+			WithBic("NWBKGB22").
+			WithAccountNumber("41426819").
+			WithNames("Samantha Holder").
+			WithAlternativeNames("Sam Holder").
+			WithAccountClassification("Personal").
+			WithSecondaryIdentification("A1B2C3D4").
+			Build()
+
+		assert.Nil(t, error)
+		assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), account.Data.ID)
+		assert.Equal(t, &form3.AccountData{
+			ID:             account.Data.ID,
+			OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+			Type:           "accounts",
+			Attributes: &form3.AccountAttributes{
+				Country:                 "GB",
+				BaseCurrency:            "GBP",
+				BankID:                  "400300",
+				BankIDCode:              "GBDSC",
+				Bic:                     "NWBKGB22",
+				AccountNumber:           "41426819",
+				Name:                    []string{"Samantha Holder"},
+				AlternativeNames:        []string{"Sam Holder"},
+				AccountClassification:   "Personal",
+				SecondaryIdentification: "A1B2C3D4",
+			},
+		}, account.Data)
+	})
+
+	t.Run("should build an account with the provided ID", func(t *testing.T) {
+		t.Parallel()
+
+		account, error := form3.NewGermanAccount("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").
+			WithID("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc").
+			WithBankID("37040044").
+			WithNames("Max Mustermann").
+			Build()
+
+		assert.Nil(t, error)
+		assert.Equal(t, "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", account.Data.ID)
+		assert.Equal(t, "DEBLZ", account.Data.Attributes.BankIDCode)
+		assert.Equal(t, "EUR", account.Data.Attributes.BaseCurrency)
+	})
+
+	t.Run("should not share the built account with the builder", func(t *testing.T) {
+		t.Parallel()
+
+		builder := form3.NewDutchAccount("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").WithBic("ABNANL2A").WithNames("Jan Jansen")
+		account, _ := builder.Build()
+		builder.WithNames("Piet Jansen")
+
+		assert.Equal(t, []string{"Jan Jansen"}, account.Data.Attributes.Name)
+	})
+
+	t.Run("should generate a new ID and copy the names every time a builder is reused", func(t *testing.T) {
+		t.Parallel()
+
+		names := []string{"Jan Jansen"}
+		alternativeNames := []string{"J. Jansen"}
+		builder := form3.NewDutchAccount("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").WithBic("ABNANL2A").WithNames(names...).WithAlternativeNames(alternativeNames...)
+
+		first, _ := builder.Build()
+		second, _ := builder.Build()
+		names[0] = "Piet Jansen"
+		alternativeNames[0] = "P. Jansen"
+
+		assert.NotEqual(t, first.Data.ID, second.Data.ID)
+		assert.Equal(t, []string{"Jan Jansen"}, first.Data.Attributes.Name)
+		assert.Equal(t, []string{"J. Jansen"}, second.Data.Attributes.AlternativeNames)
+	})
+
+	tests := []struct {
+		description string
+		builder     *form3.AccountBuilder
+		expected    form3.ValidationError
+	}{
+		{
+			description: "missing organisation",
+			builder:     form3.NewUKAccount("").WithSortCode("400300").WithBic("NWBKGB22").WithNames("Samantha Holder"),
+			expected:    form3.ValidationError{Field: "organisation_id", Message: "is required"},
+		},
+		{
+			description: "missing names",
+			builder:     form3.NewUKAccount("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").WithSortCode("400300").WithBic("NWBKGB22"),
+			expected:    form3.ValidationError{Field: "name", Message: "must have between 1 and 4 names"},
+		},
+		{
+			description: "missing required bank id",
+			builder:     form3.NewFrenchAccount("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").WithNames("Jean Dupont"),
+			expected:    form3.ValidationError{Field: "bank_id", Message: "is required in FR"},
+		},
+		{
+			description: "bank id not supported",
+			builder:     form3.NewDutchAccount("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").WithBankID("1234").WithBic("ABNANL2A").WithNames("Jan Jansen"),
+			expected:    form3.ValidationError{Field: "bank_id", Message: "is not supported in NL"},
+		},
+		{
+			description: "malformed sort code",
+			builder:     form3.NewUKAccount("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").WithSortCode("40-03-00").WithBic("NWBKGB22").WithNames("Samantha Holder"),
+			expected:    form3.ValidationError{Field: "bank_id", Message: "must match ^[0-9]{6}$"},
+		},
+		{
+			description: "missing required bic",
+			builder:     form3.NewUSAccount("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").WithBankID("021000021").WithNames("John Doe"),
+			expected:    form3.ValidationError{Field: "bic", Message: "is required in US"},
+		},
+		{
+			description: "malformed bic",
+			builder:     form3.NewAustralianAccount("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").WithBic("nope").WithNames("Bruce Wayne"),
+			expected:    form3.ValidationError{Field: "bic", Message: "must match ^([A-Z]{6}[A-Z0-9]{2}|[A-Z]{6}[A-Z0-9]{5})$"},
+		},
+		{
+			description: "malformed account number",
+			builder:     form3.NewSpanishAccount("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").WithBankID("00491500").WithAccountNumber("123").WithNames("Juan Perez"),
+			expected:    form3.ValidationError{Field: "account_number", Message: "must match ^[0-9]{10}$"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run("should not build an account when there is a "+test.description, func(t *testing.T) {
+			t.Parallel()
+
+			account, error := test.builder.Build()
+
+			assert.Nil(t, account)
+			assert.Equal(t, test.expected, error)
+		})
+	}
+}
@@ -1,13 +1,21 @@
 package form3
 
 import (
-	"fmt"
+	"context"
 	"io"
-	"net/http"
 )
 
-// resourseUri contains the path to the resource.
-const resourceUri string = "/v1/organisation/accounts"
+// accountsUri contains the path to the account resources.
+const accountsUri string = "/v1/organisation/accounts"
+
+const (
+	AccountStatusPending   = "pending"   // AccountStatusPending means the account is being set up.
+	AccountStatusConfirmed = "confirmed" // AccountStatusConfirmed means the account can be used.
+	AccountStatusFailed    = "failed"    // AccountStatusFailed means the account could not be set up.
+)
+
+// finalAccountStatuses contains the statuses after which an account status does not change anymore.
+var finalAccountStatuses = []string{AccountStatusConfirmed, AccountStatusFailed}
 
 // JsonMarshal defines the function interface that is used to marshal json.
 type JsonMarshal func(v any) ([]byte, error)
@@ -18,20 +26,23 @@ type JsonUnmarshal func(data []byte, v any) error
 // ReadAll defines the function interface that is used to read a response body.
 type ReadAll func(r io.Reader) ([]byte, error)
 
+// MutateAccount defines the function interface that is used to change an account before it is updated.
+type MutateAccount = Mutate[AccountData]
+
 // AccountService allows access to operations related to accounts.
+//
+// Accounts can be created, fetched, listed, updated and deleted.
+// The account version must match the current version of the account when it is updated or deleted, otherwise a conflict is returned.
+//
+// More details available in: https://www.api-docs.form3.tech/api/schemes/fps-direct/accounts/accounts
 type AccountService struct {
-	Client        *Client       // Used to access basic request configurations and perform http requests.
-	JsonMarshal   JsonMarshal   // Used to marshal json.
-	JsonUnmarshal JsonUnmarshal // Used to unmarshal json.
-	ReadAll       ReadAll       // Used to read the response body of a http request.
+	*ResourceService[AccountData]
 }
 
 // Represents a FORM3 account.
 //
 // More details available in: https://www.api-docs.form3.tech/api/schemes/fps-direct/accounts/accounts
-type Account struct {
-	Data *AccountData `json:"data,omitempty"`
-}
+type Account = Resource[AccountData]
 
 // Represents a FORM3 account data.
 //
@@ -65,88 +76,43 @@ type AccountAttributes struct {
 	Switched                bool     `json:"switched,omitempty"`
 }
 
-// Create allows one to create a FORM3 account.
+// WaitForStatus fetches an account until its status is one of the targets or the context is done.
 //
-// More details available in: https://www.api-docs.form3.tech/api/schemes/fps-direct/accounts/accounts/create-an-account
-func (s *AccountService) Create(account *Account) (*Account, *http.Response, error) {
-	requestURL := fmt.Sprintf("%s%s", s.Client.BaseUrl, resourceUri)
-
-	body, error := s.JsonMarshal(account)
-
-	if error != nil {
-		return nil, nil, OperationError{Message: error.Error()}
+// Confirmed and failed accounts are final unless other final statuses are set in the options,
+// an error is returned if the account reaches a final status that is not a target.
+func (s *AccountService) WaitForStatus(ctx context.Context, id string, targetStatuses []string, options WaitOptions) (*Account, *ResponseMeta, error) {
+	if options.FinalStatuses == nil {
+		options.FinalStatuses = finalAccountStatuses
 	}
 
-	return s.handleAccountResponse(http.MethodPost, requestURL, body, http.StatusCreated)
-}
-
-// Create allows one to fetch a FORM3 account.
-//
-// More details available in: https://www.api-docs.form3.tech/api/schemes/fps-direct/accounts/accounts/fetch-an-account
-func (s *AccountService) Fetch(accountId string) (*Account, *http.Response, error) {
-	requestURL := fmt.Sprintf("%s%s/%s", s.Client.BaseUrl, resourceUri, accountId)
-
-	return s.handleAccountResponse(http.MethodGet, requestURL, nil, http.StatusOK)
+	return WaitForStatus(ctx, s.ResourceService, id, accountStatus, targetStatuses, options)
 }
 
-// Create allows one to delete a FORM3 account.
-//
-// More details available in: https://www.api-docs.form3.tech/api/schemes/fps-direct/accounts/accounts/delete-an-account
-func (s *AccountService) Delete(accountId string, version int64) (*http.Response, error) {
-	requestURL := fmt.Sprintf("%s%s/%s?version=%d", s.Client.BaseUrl, resourceUri, accountId, version)
-
-	response, error := s.Client.PerformRequest(http.MethodDelete, requestURL, nil)
-
-	if error != nil {
-		return nil, error
+// accountStatus returns the status of an account, empty if unknown.
+func accountStatus(data *AccountData) string {
+	if data.Attributes == nil {
+		return ""
 	}
 
-	defer response.Body.Close()
-
-	if response.StatusCode != http.StatusNoContent {
-		body, error := s.ReadAll(response.Body)
-
-		if error != nil {
-			return response, OperationError{Message: error.Error()}
-		}
-
-		return response, OperationError{
-			Message: response.Status,
-			Body:    body,
-		}
-	}
-
-	return response, nil
+	return data.Attributes.Status
 }
 
-func (s *AccountService) handleAccountResponse(httpMethod string, requestURL string, body []byte, successfulStatusCode int) (*Account, *http.Response, error) {
-	response, error := s.Client.PerformRequest(httpMethod, requestURL, body)
-
-	if error != nil {
-		return nil, nil, error
-	}
-
-	defer response.Body.Close()
-
-	body, error = s.ReadAll(response.Body)
-
-	if error != nil {
-		return nil, response, OperationError{Message: error.Error()}
-	}
-
-	if response.StatusCode != successfulStatusCode {
-		return nil, response, OperationError{
-			Message: response.Status,
-			Body:    body,
-		}
-	}
+// ResourceID returns the account ID.
+func (d AccountData) ResourceID() string {
+	return d.ID
+}
 
-	account := &Account{}
-	error = s.JsonUnmarshal(body, &account)
+// ResourceVersion returns the account version.
+func (d AccountData) ResourceVersion() int64 {
+	return d.Version
+}
 
-	if error != nil {
-		return nil, response, OperationError{Message: error.Error()}
-	}
+// ResourceOrganisationID returns the ID of the organisation that owns the account.
+func (d AccountData) ResourceOrganisationID() string {
+	return d.OrganisationID
+}
 
-	return account, response, error
+// SetResourceOrganisationID sets the ID of the organisation that owns the account.
+func (d *AccountData) SetResourceOrganisationID(organisationId string) {
+	d.OrganisationID = organisationId
 }
@@ -3,6 +3,7 @@
 package form3_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -30,6 +31,16 @@ func TestForm3AccountsTestSuite(t *testing.T) {
 	suite.Run(t, new(Form3AccountsTestSuite))
 }
 
+func (suite *Form3AccountsTestSuite) SetupSuite() {
+	client, _ := form3.New()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if error := client.WaitUntilHealthy(ctx, time.Second); error != nil {
+		suite.T().Fatalf("The account api is not healthy: %v", error)
+	}
+}
+
 func (suite *Form3AccountsTestSuite) SetupTest() {
 	host := os.Getenv("TEST_DATABASE_HOST")
 	user := os.Getenv("TEST_DATABASE_USERNAME")
@@ -112,11 +123,12 @@ func (suite *Form3AccountsTestSuite) Test_Create() {
 	})
 
 	suite.T().Run("should not create account when there is a problem perfoming the request", func(t *testing.T) {
-		client, _ := form3.New()
-		client.BaseUrl = &url.URL{
-			Scheme: "asdf",
-			Host:   "asdf",
-		}
+		client, _ := form3.New(
+			form3.WithBaseUrl(&url.URL{
+				Scheme: "asdf",
+				Host:   "asdf",
+			}),
+		)
 
 		var account = accountFromJson(suite.T(), "./fixtures/requests/uk_account_with_confirmation_of_payee.json")
 		account.Data.ID = "0027c3aa-3aa4-4306-9efa-4b8472d875c1"
@@ -128,11 +140,12 @@ func (suite *Form3AccountsTestSuite) Test_Create() {
 	})
 
 	suite.T().Run("should not create account when a malformed url is used", func(t *testing.T) {
-		client, _ := form3.New()
-		client.BaseUrl = &url.URL{
-			Scheme: "http",
-			Host:   "/asdf.com/%%",
-		}
+		client, _ := form3.New(
+			form3.WithBaseUrl(&url.URL{
+				Scheme: "http",
+				Host:   "/asdf.com/%%",
+			}),
+		)
 
 		var account = accountFromJson(suite.T(), "./fixtures/requests/uk_account_with_confirmation_of_payee.json")
 		account.Data.ID = "0027c3aa-3aa4-4306-9efa-4b8472d875c1"
@@ -186,7 +199,7 @@ func (suite *Form3AccountsTestSuite) Test_Create() {
 		account.Data.ID = "c0582554-867d-42d3-a62e-1d64ae9f5b8e"
 		account, response, error := client.Accounts.Create(account)
 
-		assert.Equal(suite.T(), form3.OperationError{Message: "400 Bad Request", Body: []byte("{\"error_message\":\"validation failure list:\\nvalidation failure list:\\norganisation_id in body is required\"}")}, error)
+		assert.Equal(suite.T(), form3.OperationError{Message: "400 Bad Request", Body: []byte("{\"error_message\":\"validation failure list:\\nvalidation failure list:\\norganisation_id in body is required\"}"), RequestID: response.RequestID}, error)
 		assert.NotNil(suite.T(), response)
 		assert.Nil(suite.T(), account)
 	})
@@ -200,7 +213,7 @@ func (suite *Form3AccountsTestSuite) Test_Create() {
 		client.Accounts.Create(account)
 		account, response, error := client.Accounts.Create(account)
 
-		assert.Equal(suite.T(), form3.OperationError{Message: "409 Conflict", Body: []byte("{\"error_message\":\"Account cannot be created as it violates a duplicate constraint\"}")}, error)
+		assert.Equal(suite.T(), form3.OperationError{Message: "409 Conflict", Body: []byte("{\"error_message\":\"Account cannot be created as it violates a duplicate constraint\"}"), RequestID: response.RequestID}, error)
 		assert.NotNil(suite.T(), response)
 		assert.Nil(suite.T(), account)
 	})
@@ -224,11 +237,12 @@ func (suite *Form3AccountsTestSuite) Test_Fetch() {
 	})
 
 	suite.T().Run("should not fetch account when there is a problem perfoming the request", func(t *testing.T) {
-		client, _ := form3.New()
-		client.BaseUrl = &url.URL{
-			Scheme: "asdf",
-			Host:   "asdf",
-		}
+		client, _ := form3.New(
+			form3.WithBaseUrl(&url.URL{
+				Scheme: "asdf",
+				Host:   "asdf",
+			}),
+		)
 
 		var account = accountFromJson(suite.T(), "./fixtures/requests/uk_account_with_confirmation_of_payee.json")
 		account.Data.ID = "57238e6f-fc28-4d63-8e31-d901882b104f"
@@ -248,17 +262,18 @@ func (suite *Form3AccountsTestSuite) Test_Fetch() {
 		account.Data.ID = "f65b0db1-50b9-4ef3-81b4-1a9442d75d0c"
 		account, response, error := client.Accounts.Fetch(account.Data.ID)
 
-		assert.Equal(suite.T(), form3.OperationError{Message: "404 Not Found", Body: []byte("{\"error_message\":\"record f65b0db1-50b9-4ef3-81b4-1a9442d75d0c does not exist\"}")}, error)
+		assert.Equal(suite.T(), form3.OperationError{Message: "404 Not Found", Body: []byte("{\"error_message\":\"record f65b0db1-50b9-4ef3-81b4-1a9442d75d0c does not exist\"}"), RequestID: response.RequestID}, error)
 		assert.NotNil(suite.T(), response)
 		assert.Nil(suite.T(), account)
 	})
 
 	suite.T().Run("should not fetch account when there is a problem perfoming the request", func(t *testing.T) {
-		client, _ := form3.New()
-		client.BaseUrl = &url.URL{
-			Scheme: "asdf",
-			Host:   "asdf",
-		}
+		client, _ := form3.New(
+			form3.WithBaseUrl(&url.URL{
+				Scheme: "asdf",
+				Host:   "asdf",
+			}),
+		)
 
 		var account = accountFromJson(suite.T(), "./fixtures/requests/uk_account_with_confirmation_of_payee.json")
 		account.Data.ID = "26eeb841-edd5-4d9e-947f-db60f91a7085"
@@ -270,11 +285,12 @@ func (suite *Form3AccountsTestSuite) Test_Fetch() {
 	})
 
 	suite.T().Run("should not fetch account when a malformed url is used", func(t *testing.T) {
-		client, _ := form3.New()
-		client.BaseUrl = &url.URL{
-			Scheme: "http",
-			Host:   "/asdf.com/%%",
-		}
+		client, _ := form3.New(
+			form3.WithBaseUrl(&url.URL{
+				Scheme: "http",
+				Host:   "/asdf.com/%%",
+			}),
+		)
 
 		var account = accountFromJson(suite.T(), "./fixtures/requests/uk_account_with_confirmation_of_payee.json")
 		account.Data.ID = "26eeb841-edd5-4d9e-947f-db60f91a7085"
@@ -343,12 +359,12 @@ func (suite *Form3AccountsTestSuite) Test_Delete() {
 	})
 
 	suite.T().Run("should not delete account when there is a problem performing the request", func(*testing.T) {
-		client, _ := form3.New()
-
-		client.BaseUrl = &url.URL{
-			Scheme: "asdf",
-			Host:   "asdf",
-		}
+		client, _ := form3.New(
+			form3.WithBaseUrl(&url.URL{
+				Scheme: "asdf",
+				Host:   "asdf",
+			}),
+		)
 
 		account := accountFromJson(suite.T(), "./fixtures/requests/uk_account_with_confirmation_of_payee.json")
 		account.Data.ID = "b0a7d0e2-ca99-42de-8655-1e4ff0794cb2"
@@ -361,11 +377,12 @@ func (suite *Form3AccountsTestSuite) Test_Delete() {
 	})
 
 	suite.T().Run("should not delete account when a malformed url is used", func(t *testing.T) {
-		client, _ := form3.New()
-		client.BaseUrl = &url.URL{
-			Scheme: "http",
-			Host:   "/asdf.com/%%",
-		}
+		client, _ := form3.New(
+			form3.WithBaseUrl(&url.URL{
+				Scheme: "http",
+				Host:   "/asdf.com/%%",
+			}),
+		)
 
 		account := accountFromJson(suite.T(), "./fixtures/requests/uk_account_with_confirmation_of_payee.json")
 		account.Data.ID = "b0a7d0e2-ca99-42de-8655-1e4ff0794cb2"
@@ -382,7 +399,7 @@ func (suite *Form3AccountsTestSuite) Test_Delete() {
 
 		response, error := client.Accounts.Delete("5faad046-ca12-475b-be4e-425c9668d3ab", 0)
 
-		assert.Equal(suite.T(), form3.OperationError{Message: "404 Not Found", Body: []byte{}}, error)
+		assert.Equal(suite.T(), form3.OperationError{Message: "404 Not Found", Body: []byte{}, RequestID: response.RequestID}, error)
 		assert.NotNil(suite.T(), response)
 	})
 
@@ -428,14 +445,14 @@ func TestAccountsWithMocks_Create(t *testing.T) {
 		mockLogDebugMessage := new(LogDebugMessageMock)
 		mockLogDebugMessage.On("LogDebugMessage", mock.Anything, mock.Anything).Return()
 
-		client, _ := form3.New()
-		client.HttpTimeout = 100 * time.Second
-		client.HttpTimeUntilNextAttempt = 50 * time.Microsecond
-		client.HttpRetryAttempts = 5
-		client.DebugEnabled = true
-		rand.Seed(0)
-
-		client.LogDebugMessage = mockLogDebugMessage.LogDebugMessage
+		client, _ := form3.New(
+			form3.WithOperationTimeout(100*time.Second),
+			form3.WithHttpTimeUntilNextAttempt(50*time.Microsecond),
+			form3.WithHttpRetryAttempts(5),
+			form3.WithDebugEnabled(true),
+			form3.WithHttpRetryJitterRandomSeed(rand.NewSource(0)),
+			form3.WithLogDebugMessage(mockLogDebugMessage.LogDebugMessage),
+		)
 
 		for i := 0; i <= 3; i++ {
 			gock.New("http://accountapi:8080").
@@ -473,14 +490,15 @@ func TestAccountsWithMocks_Fetch(t *testing.T) {
 		mockLogDebugMessage := new(LogDebugMessageMock)
 		mockLogDebugMessage.On("LogDebugMessage", mock.Anything, mock.Anything).Return()
 
-		client, _ := form3.New()
-		client.HttpTimeout = 100 * time.Second
-		client.HttpTimeUntilNextAttempt = 50 * time.Microsecond
-		client.HttpRetryAttempts = 5
-		client.DebugEnabled = true
-		rand.Seed(0)
+		client, _ := form3.New(
+			form3.WithOperationTimeout(100*time.Second),
+			form3.WithHttpTimeUntilNextAttempt(50*time.Microsecond),
+			form3.WithHttpRetryAttempts(5),
+			form3.WithDebugEnabled(true),
+			form3.WithHttpRetryJitterRandomSeed(rand.NewSource(0)),
+			form3.WithLogDebugMessage(mockLogDebugMessage.LogDebugMessage),
+		)
 
-		client.LogDebugMessage = mockLogDebugMessage.LogDebugMessage
 		accountUuid := "42069a76-37e6-47b4-8756-957a3238676d"
 
 		account := &form3.Account{
@@ -519,14 +537,15 @@ func TestAccountsWithMocks_Delete(t *testing.T) {
 		mockLogDebugMessage := new(LogDebugMessageMock)
 		mockLogDebugMessage.On("LogDebugMessage", mock.Anything, mock.Anything).Return()
 
-		client, _ := form3.New()
-		client.HttpTimeout = 100 * time.Second
-		client.HttpTimeUntilNextAttempt = 50 * time.Microsecond
-		client.HttpRetryAttempts = 5
-		client.DebugEnabled = true
-		rand.Seed(0)
+		client, _ := form3.New(
+			form3.WithOperationTimeout(100*time.Second),
+			form3.WithHttpTimeUntilNextAttempt(50*time.Microsecond),
+			form3.WithHttpRetryAttempts(5),
+			form3.WithDebugEnabled(true),
+			form3.WithHttpRetryJitterRandomSeed(rand.NewSource(0)),
+			form3.WithLogDebugMessage(mockLogDebugMessage.LogDebugMessage),
+		)
 
-		client.LogDebugMessage = mockLogDebugMessage.LogDebugMessage
 		accountUuid := "c0ca9748-06d5-4e7d-a97c-4141a465b26d"
 		version := 0
 
@@ -552,3 +571,143 @@ func TestAccountsWithMocks_Delete(t *testing.T) {
 		assert.Equal(t, 204, response.StatusCode)
 	})
 }
+
+func TestAccountsWithMocks_UpdateWithRetry(t *testing.T) {
+	t.Run("should update the account and fetch it again when there is a version conflict", func(*testing.T) {
+		defer gock.Off()
+		client, _ := form3.New()
+		accountUuid := "0b3b5e5e-4a06-4b3a-9f5d-2f1b8b1c1a61"
+		accountPath := fmt.Sprintf("/v1/organisation/accounts/%s", accountUuid)
+
+		gock.New("http://accountapi:8080").
+			Get(accountPath).
+			Reply(200).
+			BodyString(fmt.Sprintf("{\"data\": {\"id\": \"%s\", \"version\": 0}}", accountUuid))
+
+		gock.New("http://accountapi:8080").
+			Patch(accountPath).
+			Reply(409)
+
+		gock.New("http://accountapi:8080").
+			Get(accountPath).
+			Reply(200).
+			BodyString(fmt.Sprintf("{\"data\": {\"id\": \"%s\", \"version\": 1}}", accountUuid))
+
+		gock.New("http://accountapi:8080").
+			Patch(accountPath).
+			Reply(200).
+			BodyString(fmt.Sprintf("{\"data\": {\"id\": \"%s\", \"version\": 2, \"attributes\": {\"name\": [\"Samantha Holder\"]}}}", accountUuid))
+
+		updatedAccount, response, error := client.Accounts.UpdateWithRetry(accountUuid, func(account *form3.Account) error {
+			account.Data.Attributes = &form3.AccountAttributes{Name: []string{"Samantha Holder"}}
+
+			return nil
+		})
+
+		assert.Nil(t, error)
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, int64(2), updatedAccount.Data.Version)
+		assert.Equal(t, []string{"Samantha Holder"}, updatedAccount.Data.Attributes.Name)
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("should not update the account when conflicts persist after all attempts", func(*testing.T) {
+		defer gock.Off()
+		client, _ := form3.New(form3.WithConflictRetryAttempts(1))
+		accountUuid := "6f1f7a0c-0d44-4c35-9c09-0c8a2b8e84a2"
+		accountPath := fmt.Sprintf("/v1/organisation/accounts/%s", accountUuid)
+
+		for i := 0; i <= 1; i++ {
+			gock.New("http://accountapi:8080").
+				Get(accountPath).
+				Reply(200).
+				BodyString(fmt.Sprintf("{\"data\": {\"id\": \"%s\", \"version\": %d}}", accountUuid, i))
+
+			gock.New("http://accountapi:8080").
+				Patch(accountPath).
+				Reply(409).
+				BodyString("{\"error_message\":\"invalid version\"}")
+		}
+
+		updatedAccount, response, error := client.Accounts.UpdateWithRetry(accountUuid, func(account *form3.Account) error {
+			return nil
+		})
+
+		assert.Equal(t, form3.OperationError{Message: "409 Conflict", Body: []byte("{\"error_message\":\"invalid version\"}")}, error)
+		assert.Equal(t, 409, response.StatusCode)
+		assert.Nil(t, updatedAccount)
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("should not update the account when changing it fails", func(*testing.T) {
+		defer gock.Off()
+		client, _ := form3.New()
+		accountUuid := "a8d1a3c7-7f0f-4b5e-8e34-6c1b9f3f2d10"
+
+		gock.New("http://accountapi:8080").
+			Get(fmt.Sprintf("/v1/organisation/accounts/%s", accountUuid)).
+			Reply(200).
+			BodyString(fmt.Sprintf("{\"data\": {\"id\": \"%s\"}}", accountUuid))
+
+		mutateError := fmt.Errorf("mutation issue")
+
+		updatedAccount, response, error := client.Accounts.UpdateWithRetry(accountUuid, func(account *form3.Account) error {
+			return mutateError
+		})
+
+		assert.Equal(t, form3.OperationError{Message: "mutation issue", Cause: mutateError}, error)
+		assert.NotNil(t, response)
+		assert.Nil(t, updatedAccount)
+	})
+}
+
+func TestAccountsWithMocks_DeleteLatest(t *testing.T) {
+	t.Run("should delete the account using the latest version and fetch it again when there is a version conflict", func(*testing.T) {
+		defer gock.Off()
+		client, _ := form3.New()
+		accountUuid := "1d0b6f8e-3c1e-4d8a-b0e6-7b3a9f4c5e21"
+		accountPath := fmt.Sprintf("/v1/organisation/accounts/%s", accountUuid)
+
+		gock.New("http://accountapi:8080").
+			Get(accountPath).
+			Reply(200).
+			BodyString(fmt.Sprintf("{\"data\": {\"id\": \"%s\", \"version\": 3}}", accountUuid))
+
+		gock.New("http://accountapi:8080").
+			Delete(accountPath).
+			MatchParam("version", "3").
+			Reply(409)
+
+		gock.New("http://accountapi:8080").
+			Get(accountPath).
+			Reply(200).
+			BodyString(fmt.Sprintf("{\"data\": {\"id\": \"%s\", \"version\": 4}}", accountUuid))
+
+		gock.New("http://accountapi:8080").
+			Delete(accountPath).
+			MatchParam("version", "4").
+			Reply(204)
+
+		response, error := client.Accounts.DeleteLatest(accountUuid)
+
+		assert.Nil(t, error)
+		assert.Equal(t, 204, response.StatusCode)
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("should not delete the account when it does not exist", func(*testing.T) {
+		defer gock.Off()
+		client, _ := form3.New()
+		accountUuid := "7c2e4b1a-9d3f-4e6a-8b5c-0f1e2d3c4b5a"
+
+		gock.New("http://accountapi:8080").
+			Get(fmt.Sprintf("/v1/organisation/accounts/%s", accountUuid)).
+			Reply(404).
+			BodyString("{\"error_message\":\"not found\"}")
+
+		response, error := client.Accounts.DeleteLatest(accountUuid)
+
+		assert.Equal(t, form3.OperationError{Message: "404 Not Found", Body: []byte("{\"error_message\":\"not found\"}")}, error)
+		assert.Equal(t, 404, response.StatusCode)
+	})
+}
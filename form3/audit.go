@@ -0,0 +1,164 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// auditUri contains the path to the audit entries.
+const auditUri string = "/v1/audit/entries"
+
+// auditPageSize is the number of audit entries listed per page when fetching the history of a record.
+const auditPageSize = 100
+
+// AuditAction is the change made to a record that was audited.
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create" // AuditActionCreate means the record was created.
+	AuditActionUpdate AuditAction = "update" // AuditActionUpdate means the record was updated.
+	AuditActionDelete AuditAction = "delete" // AuditActionDelete means the record was deleted.
+)
+
+// AuditService allows one to know who changed a record and when.
+//
+// Audit entries are read only and belong to a record, identified by its record type and ID.
+//
+// More details available in: https://www.api-docs.form3.tech/api/platform/audit/audit-entries
+type AuditService struct {
+	Client *Client
+}
+
+// Represents a FORM3 audit entry.
+type AuditEntry = Resource[AuditEntryData]
+
+// Represents a FORM3 audit entry data.
+type AuditEntryData struct {
+	Attributes *AuditEntryAttributes `json:"attributes,omitempty"`
+	OrganisationResourceFields
+}
+
+// Represents a FORM3 audit entry attributes.
+//
+// BeforeData and AfterData contain the record data before and after the change, missing when the record
+// was created or deleted respectively.
+type AuditEntryAttributes struct {
+	Action     AuditAction     `json:"action,omitempty"`
+	ActionTime time.Time       `json:"action_time"`
+	ActionedBy string          `json:"actioned_by,omitempty"`
+	AfterData  json.RawMessage `json:"after_data,omitempty"`
+	BeforeData json.RawMessage `json:"before_data,omitempty"`
+	RecordID   string          `json:"record_id,omitempty"`
+	RecordType RecordType      `json:"record_type,omitempty"`
+}
+
+// AccountChange is a change made to an account, decoded from an audit entry.
+type AccountChange struct {
+	Actor  string       // ID of the user who made the change.
+	Action AuditAction  // Change that was made.
+	Time   time.Time    // When the change was made.
+	Before *AccountData // Account before the change, nil if it was created.
+	After  *AccountData // Account after the change, nil if it was deleted.
+}
+
+// Entries allows access to the audit entries of a record.
+func (s *AuditService) Entries(recordType RecordType, recordId string) *ReadOnlyResourceService[AuditEntryData] {
+	return NewReadOnlyResourceService[AuditEntryData](s.Client, fmt.Sprintf("%s/%s/%s", auditUri, recordType, recordId), "audit entry")
+}
+
+// History allows one to fetch every audit entry of a record, from the oldest to the most recent change.
+//
+// Every page of entries is fetched, the response details are the ones of the last page.
+func (s *AuditService) History(recordType RecordType, recordId string) ([]AuditEntryData, *ResponseMeta, error) {
+	return s.HistoryWithContext(context.Background(), recordType, recordId)
+}
+
+// HistoryWithContext is like History but the requests are cancelled once the provided context is done.
+func (s *AuditService) HistoryWithContext(ctx context.Context, recordType RecordType, recordId string) ([]AuditEntryData, *ResponseMeta, error) {
+	return auditHistory(ctx, s.Entries(recordType, recordId))
+}
+
+// auditHistory lists every page of audit entries, sorted from the oldest to the most recent change.
+func auditHistory(ctx context.Context, entries *ReadOnlyResourceService[AuditEntryData]) ([]AuditEntryData, *ResponseMeta, error) {
+	history, response, error := listAll(ctx, entries.ListWithContext, ListOptions{PageSize: auditPageSize})
+
+	if error != nil {
+		return nil, response, error
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return auditActionTime(history[i]).Before(auditActionTime(history[j]))
+	})
+
+	return history, response, nil
+}
+
+// History allows one to fetch every change made to an account, from the oldest to the most recent one.
+func (s *AccountService) History(id string) ([]AccountChange, *ResponseMeta, error) {
+	return s.HistoryWithContext(context.Background(), id)
+}
+
+// HistoryWithContext is like History but the requests are cancelled once the provided context is done.
+func (s *AccountService) HistoryWithContext(ctx context.Context, id string) ([]AccountChange, *ResponseMeta, error) {
+	// Only the changes made within the organisation of the service are listed, if any
+	entries := s.Client.Audit.Entries(RecordTypeAccounts, id)
+	entries.resources = entries.resources.forOrganisation(s.organisation)
+
+	history, response, error := auditHistory(ctx, entries)
+
+	if error != nil {
+		return nil, response, error
+	}
+
+	changes := make([]AccountChange, 0, len(history))
+
+	for _, entry := range history {
+		if entry.Attributes == nil {
+			continue
+		}
+
+		change := AccountChange{Actor: entry.Attributes.ActionedBy, Action: entry.Attributes.Action, Time: entry.Attributes.ActionTime}
+
+		change.Before, error = decodeAuditSnapshot[AccountData](entry.Attributes.BeforeData)
+
+		if error == nil {
+			change.After, error = decodeAuditSnapshot[AccountData](entry.Attributes.AfterData)
+		}
+
+		if error != nil {
+			return nil, response, OperationError{Message: fmt.Sprintf("audit entry %s could not be decoded: %s", entry.ID, error.Error())}
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, response, nil
+}
+
+// decodeAuditSnapshot decodes the record data of an audit entry, returning nil if there is none.
+func decodeAuditSnapshot[T any](snapshot json.RawMessage) (*T, error) {
+	if len(snapshot) == 0 || string(snapshot) == "null" {
+		return nil, nil
+	}
+
+	data := new(T)
+	error := json.Unmarshal(snapshot, data)
+
+	if error != nil {
+		return nil, error
+	}
+
+	return data, nil
+}
+
+// auditActionTime returns when the change of an audit entry was made, the zero time if unknown.
+func auditActionTime(entry AuditEntryData) time.Time {
+	if entry.Attributes == nil {
+		return time.Time{}
+	}
+
+	return entry.Attributes.ActionTime
+}
@@ -0,0 +1,123 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
+	"github.com/stretchr/testify/assert"
+)
+
+const auditedAccountID = "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+
+func newAuditEntry(t *testing.T, id string, action form3.AuditAction, actionTime time.Time, before *form3.AccountData, after *form3.AccountData) *form3.AuditEntry {
+	encode := func(data *form3.AccountData) json.RawMessage {
+		if data == nil {
+			return nil
+		}
+
+		encoded, error := json.Marshal(data)
+
+		assert.Nil(t, error)
+
+		return encoded
+	}
+
+	return &form3.AuditEntry{Data: &form3.AuditEntryData{
+		OrganisationResourceFields: form3.OrganisationResourceFields{ID: id, Type: "audit_entries"},
+		Attributes: &form3.AuditEntryAttributes{
+			Action:     action,
+			ActionTime: actionTime,
+			ActionedBy: "5f7e3c1a-2b4d-4e6f-8a9b-0c1d2e3f4a5b",
+			BeforeData: encode(before),
+			AfterData:  encode(after),
+			RecordID:   auditedAccountID,
+			RecordType: form3.RecordTypeAccounts,
+		},
+	}}
+}
+
+func TestAudit(t *testing.T) {
+	t.Run("should fetch the history of an account from the oldest change", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()))
+		entries := form3.NewResourceService[form3.AuditEntryData](client, "/v1/audit/entries/accounts/"+auditedAccountID, "audit entry")
+		created := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+
+		closed := &form3.AccountData{ID: auditedAccountID, Version: 1, Attributes: &form3.AccountAttributes{Status: "closed"}}
+		confirmed := &form3.AccountData{ID: auditedAccountID, Attributes: &form3.AccountAttributes{Status: "confirmed"}}
+
+		for _, entry := range []*form3.AuditEntry{
+			newAuditEntry(t, "0b5e1c3a-1f2d-4e3c-9a8b-7c6d5e4f3a21", form3.AuditActionUpdate, created.Add(time.Hour), confirmed, closed),
+			newAuditEntry(t, "1c6f2d4b-2a3e-4f4d-8b9c-8d7e6f5a4b32", form3.AuditActionCreate, created, nil, confirmed),
+		} {
+			_, _, error := entries.Create(entry)
+
+			assert.Nil(t, error)
+		}
+
+		changes, response, error := client.Accounts.History(auditedAccountID)
+
+		assert.Nil(t, error)
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, []form3.AccountChange{
+			{Actor: "5f7e3c1a-2b4d-4e6f-8a9b-0c1d2e3f4a5b", Action: form3.AuditActionCreate, Time: created, After: confirmed},
+			{Actor: "5f7e3c1a-2b4d-4e6f-8a9b-0c1d2e3f4a5b", Action: form3.AuditActionUpdate, Time: created.Add(time.Hour), Before: confirmed, After: closed},
+		}, changes)
+	})
+
+	t.Run("should fetch every page of audit entries", func(t *testing.T) {
+		t.Parallel()
+
+		pages := []string{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pageNumber := r.URL.Query().Get("page[number]")
+
+			if pageNumber == "" {
+				pageNumber = "0"
+			}
+
+			pages = append(pages, pageNumber)
+			next := ""
+
+			if pageNumber == "0" {
+				next = `,"next":"/v1/audit/entries/payments/4ee3a8d8?page[number]=1"`
+			}
+
+			fmt.Fprintf(w, `{"data":[{"id":"entry-%s","type":"audit_entries","attributes":{"action":"update","record_type":"payments"}}],"links":{"self":"/v1/audit/entries"%s}}`, pageNumber, next)
+		}))
+		defer server.Close()
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+
+		history, _, error := client.Audit.History(form3.RecordTypePayments, "4ee3a8d8")
+
+		assert.Nil(t, error)
+		assert.Equal(t, []string{"0", "1"}, pages)
+		assert.Len(t, history, 2)
+		assert.Equal(t, "entry-0", history[0].ID)
+		assert.Equal(t, "/v1/audit/entries/payments/4ee3a8d8", client.Audit.Entries(form3.RecordTypePayments, "4ee3a8d8").Path())
+	})
+
+	t.Run("should fail when an account snapshot cannot be decoded", func(t *testing.T) {
+		t.Parallel()
+
+		server, _, _ := newResourceServer(t, 200, `{"data":[{"id":"0b5e1c3a","type":"audit_entries","attributes":{"action":"update","after_data":"closed"}}]}`)
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+
+		changes, response, error := client.Accounts.History(auditedAccountID)
+
+		assert.Nil(t, changes)
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, form3.OperationError{Message: "audit entry 0b5e1c3a could not be decoded: json: cannot unmarshal string into Go value of type form3.AccountData"}, error)
+	})
+}
@@ -0,0 +1,119 @@
+package form3
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	bankIDsUri string = "/v1/directory/bankids" // bankIDsUri contains the path to the bank ID directory.
+	bicsUri    string = "/v1/directory/bics"    // bicsUri contains the path to the BIC directory.
+)
+
+// BankIDService allows one to look up the institutions identified by bank IDs, like sort codes or BLZs.
+//
+// The directory is read only, its entries can only be fetched and listed.
+//
+// More details available in: https://www.api-docs.form3.tech/api/platform/directories/bank-ids
+type BankIDService struct {
+	*ReadOnlyResourceService[BankIDData]
+}
+
+// BicService allows one to look up the institutions identified by SWIFT BICs.
+//
+// The directory is read only, its entries can only be fetched and listed.
+//
+// More details available in: https://www.api-docs.form3.tech/api/platform/directories/bics
+type BicService struct {
+	*ReadOnlyResourceService[BicData]
+}
+
+// Represents a FORM3 bank ID directory entry.
+type BankID = Resource[BankIDData]
+
+// Represents a FORM3 bank ID directory entry data.
+type BankIDData struct {
+	Attributes *BankIDAttributes `json:"attributes,omitempty"`
+	ResourceFields
+}
+
+// Represents a FORM3 bank ID directory entry attributes.
+//
+// Schemes contains the payment schemes the institution can be reached by, for example "FPS" or "BACS".
+type BankIDAttributes struct {
+	BankID     string   `json:"bank_id,omitempty"`
+	BankIDCode string   `json:"bank_id_code,omitempty"`
+	Bic        string   `json:"bic,omitempty"`
+	Country    string   `json:"country,omitempty"`
+	Name       string   `json:"name,omitempty"`
+	Schemes    []string `json:"schemes,omitempty"`
+}
+
+// Represents a FORM3 BIC directory entry.
+type Bic = Resource[BicData]
+
+// Represents a FORM3 BIC directory entry data.
+type BicData struct {
+	Attributes *BicAttributes `json:"attributes,omitempty"`
+	ResourceFields
+}
+
+// Represents a FORM3 BIC directory entry attributes.
+type BicAttributes struct {
+	Bic     string `json:"bic,omitempty"`
+	Country string `json:"country,omitempty"`
+	Name    string `json:"name,omitempty"`
+}
+
+// Lookup allows one to find the institution identified by a bank ID in a country.
+//
+// Spaces and dashes are ignored, so that sort codes can be written as "40-03-00".
+// An OperationError is returned if the bank ID is not in the directory.
+func (s *BankIDService) Lookup(country string, bankId string) (*BankIDData, *ResponseMeta, error) {
+	return s.LookupWithContext(context.Background(), country, bankId)
+}
+
+// LookupWithContext is like Lookup but the request is cancelled once the provided context is done.
+func (s *BankIDService) LookupWithContext(ctx context.Context, country string, bankId string) (*BankIDData, *ResponseMeta, error) {
+	bankId = strings.NewReplacer(" ", "", "-", "").Replace(bankId)
+
+	entries, response, error := s.ListWithContext(ctx, ListOptions{
+		PageSize: 1,
+		Filter:   map[string]string{"country": strings.ToUpper(country), "bank_id": bankId},
+	})
+
+	if error != nil {
+		return nil, response, error
+	}
+
+	if len(entries.Data) == 0 {
+		return nil, response, OperationError{Message: fmt.Sprintf("bank id %s was not found in %s", bankId, country)}
+	}
+
+	return &entries.Data[0], response, nil
+}
+
+// Lookup allows one to find the institution identified by a BIC.
+//
+// An OperationError is returned if the BIC is not in the directory.
+func (s *BicService) Lookup(bic string) (*BicData, *ResponseMeta, error) {
+	return s.LookupWithContext(context.Background(), bic)
+}
+
+// LookupWithContext is like Lookup but the request is cancelled once the provided context is done.
+func (s *BicService) LookupWithContext(ctx context.Context, bic string) (*BicData, *ResponseMeta, error) {
+	bic = strings.ToUpper(strings.TrimSpace(bic))
+
+	entries, response, error := s.ListWithContext(ctx, ListOptions{PageSize: 1, Filter: map[string]string{"bic": bic}})
+
+	if error != nil {
+		return nil, response, error
+	}
+
+	if len(entries.Data) == 0 {
+		return nil, response, OperationError{Message: fmt.Sprintf("bic %s was not found", bic)}
+	}
+
+	return &entries.Data[0], response, nil
+}
@@ -0,0 +1,61 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"testing"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBankDirectory(t *testing.T) {
+	t.Run("should look up a sort code", func(t *testing.T) {
+		t.Parallel()
+
+		server, request, _ := newResourceServer(t, 200, `{"data":[{"id":"c1f5a3b2-7d4e-4f6a-9b8c-0d1e2f3a4b5c","type":"bankids","attributes":{"bank_id":"400300","bank_id_code":"GBDSC","bic":"NWBKGB22","country":"GB","name":"National Westminster Bank","schemes":["FPS","BACS"]}}]}`)
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+
+		bankId, response, error := client.BankIDs.Lookup("gb", "40-03-00")
+
+		assert.Nil(t, error)
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, "/v1/directory/bankids", request.URL.Path)
+		assert.Equal(t, "filter%5Bbank_id%5D=400300&filter%5Bcountry%5D=GB&page%5Bsize%5D=1", request.URL.RawQuery)
+		assert.Equal(t, "NWBKGB22", bankId.Attributes.Bic)
+		assert.Equal(t, []string{"FPS", "BACS"}, bankId.Attributes.Schemes)
+	})
+
+	t.Run("should fail to look up an unknown sort code", func(t *testing.T) {
+		t.Parallel()
+
+		server, _, _ := newResourceServer(t, 200, `{"data":[]}`)
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+
+		bankId, response, error := client.BankIDs.Lookup("GB", "999999")
+
+		assert.Nil(t, bankId)
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, form3.OperationError{Message: "bank id 999999 was not found in GB"}, error)
+	})
+
+	t.Run("should look up and fetch a BIC", func(t *testing.T) {
+		t.Parallel()
+
+		server, request, _ := newResourceServer(t, 200, `{"data":[{"id":"e2a4c6b8-1d3f-4a5b-8c7d-9e0f1a2b3c4d","type":"bics","attributes":{"bic":"NWBKGB22","country":"GB","name":"National Westminster Bank"}}]}`)
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+
+		bic, _, error := client.Bics.Lookup(" nwbkgb22 ")
+
+		assert.Nil(t, error)
+		assert.Equal(t, "filter%5Bbic%5D=NWBKGB22&page%5Bsize%5D=1", request.URL.RawQuery)
+		assert.Equal(t, "National Westminster Bank", bic.Attributes.Name)
+
+		bics, _, error := client.Bics.List(form3.ListOptions{Filter: map[string]string{"country": "GB"}})
+
+		assert.Nil(t, error)
+		assert.Equal(t, "/v1/directory/bics", client.Bics.Path())
+		assert.Equal(t, "filter%5Bcountry%5D=GB", request.URL.RawQuery)
+		assert.Len(t, bics.Data, 1)
+	})
+}
@@ -0,0 +1,230 @@
+// Package bankdirectory resolves bank IDs and BICs to institutions offline, using a loadable dataset.
+//
+// It is useful to choose the bank ID, BIC and bank ID code of an account before creating it,
+// without performing a request for every lookup. The online directories are available in form3.BankIDService
+// and form3.BicService.
+package bankdirectory
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+)
+
+// columns are the columns a dataset must have in its header, in any order.
+var columns = []string{"country", "bank_id_code", "bank_id", "bic", "name", "schemes"}
+
+// Institution is a financial institution, identified by a bank ID in a country.
+type Institution struct {
+	Country    string   // ISO 3166-1 alpha-2 country code.
+	BankIDCode string   // Identifies the type of bank ID, for example "GBDSC" for sort codes.
+	BankID     string   // Bank ID, for example a sort code or a BLZ.
+	Bic        string   // SWIFT BIC of the institution, if any.
+	Name       string   // Name of the institution.
+	Schemes    []string // Payment schemes the institution can be reached by, for example "FPS" or "BACS".
+}
+
+// Reaches returns if the institution can be reached by a payment scheme.
+func (i Institution) Reaches(scheme string) bool {
+	for _, reachable := range i.Schemes {
+		if strings.EqualFold(reachable, scheme) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Apply sets the bank ID and the BIC of the institution on an account being built.
+func (i Institution) Apply(builder *form3.AccountBuilder) *form3.AccountBuilder {
+	return builder.WithBankID(i.BankID).WithBic(i.Bic)
+}
+
+// Directory finds institutions by bank ID or by BIC.
+//
+// It is safe to be used by multiple goroutines.
+type Directory struct {
+	mutex   sync.RWMutex
+	bankIDs map[string]Institution   // Institutions by country and bank ID.
+	bics    map[string][]Institution // Institutions by BIC, without the branch code if it is "XXX".
+	entries int
+}
+
+// New creates a directory containing the given institutions.
+func New(institutions ...Institution) *Directory {
+	d := &Directory{bankIDs: map[string]Institution{}, bics: map[string][]Institution{}}
+
+	for _, institution := range institutions {
+		d.Add(institution)
+	}
+
+	return d
+}
+
+// Load creates a directory from a CSV dataset.
+//
+// The first line is a header with the columns country, bank_id_code, bank_id, bic, name and schemes, in any order.
+// Schemes are separated by semicolons, for example "FPS;BACS;CHAPS".
+func Load(reader io.Reader) (*Directory, error) {
+	records := csv.NewReader(reader)
+	records.TrimLeadingSpace = true
+
+	header, error := records.Read()
+
+	if error != nil {
+		return nil, fmt.Errorf("dataset header could not be read: %w", error)
+	}
+
+	positions := map[string]int{}
+
+	for position, column := range header {
+		positions[strings.ToLower(strings.TrimSpace(column))] = position
+	}
+
+	for _, column := range columns {
+		if _, ok := positions[column]; !ok {
+			return nil, fmt.Errorf("dataset header is missing the %s column", column)
+		}
+	}
+
+	d := New()
+
+	for {
+		record, error := records.Read()
+
+		if error == io.EOF {
+			return d, nil
+		}
+
+		if error != nil {
+			return nil, fmt.Errorf("dataset could not be read: %w", error)
+		}
+
+		schemes := []string{}
+
+		for _, scheme := range strings.Split(record[positions["schemes"]], ";") {
+			if scheme = strings.TrimSpace(scheme); scheme != "" {
+				schemes = append(schemes, scheme)
+			}
+		}
+
+		d.Add(Institution{
+			Country:    record[positions["country"]],
+			BankIDCode: record[positions["bank_id_code"]],
+			BankID:     record[positions["bank_id"]],
+			Bic:        record[positions["bic"]],
+			Name:       record[positions["name"]],
+			Schemes:    schemes,
+		})
+	}
+}
+
+// LoadFile creates a directory from a CSV dataset file, following the format described in Load.
+func LoadFile(path string) (*Directory, error) {
+	file, error := os.Open(path)
+
+	if error != nil {
+		return nil, error
+	}
+
+	defer file.Close()
+
+	return Load(file)
+}
+
+// Add adds an institution to the directory, replacing the institution with the same country and bank ID, if any.
+func (d *Directory) Add(institution Institution) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	institution.Country = strings.ToUpper(strings.TrimSpace(institution.Country))
+	institution.BankID = normalizeBankID(institution.BankID)
+	institution.Bic = strings.ToUpper(strings.TrimSpace(institution.Bic))
+	key := bankIDKey(institution.Country, institution.BankID)
+
+	if previous, exists := d.bankIDs[key]; exists {
+		d.removeBic(previous)
+	} else {
+		d.entries++
+	}
+
+	d.bankIDs[key] = institution
+
+	if institution.Bic != "" {
+		bic := normalizeBic(institution.Bic)
+		d.bics[bic] = append(d.bics[bic], institution)
+	}
+}
+
+// Len returns how many institutions the directory contains.
+func (d *Directory) Len() int {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.entries
+}
+
+// LookupBankID finds the institution identified by a bank ID in a country.
+//
+// Spaces and dashes are ignored, so that sort codes can be written as "40-03-00".
+func (d *Directory) LookupBankID(country string, bankId string) (Institution, bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	institution, ok := d.bankIDs[bankIDKey(strings.ToUpper(strings.TrimSpace(country)), normalizeBankID(bankId))]
+
+	return institution, ok
+}
+
+// LookupBic finds the institutions identified by a BIC.
+//
+// A BIC without a branch code matches the BIC of the primary office, for example "NWBKGB22" matches "NWBKGB22XXX".
+func (d *Directory) LookupBic(bic string) []Institution {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return append([]Institution{}, d.bics[normalizeBic(bic)]...)
+}
+
+// removeBic removes an institution from the BIC index, must only be used while holding the mutex.
+func (d *Directory) removeBic(institution Institution) {
+	bic := normalizeBic(institution.Bic)
+	remaining := []Institution{}
+
+	for _, indexed := range d.bics[bic] {
+		if indexed.Country != institution.Country || indexed.BankID != institution.BankID {
+			remaining = append(remaining, indexed)
+		}
+	}
+
+	if len(remaining) == 0 {
+		delete(d.bics, bic)
+
+		return
+	}
+
+	d.bics[bic] = remaining
+}
+
+func bankIDKey(country string, bankId string) string {
+	return fmt.Sprintf("%s/%s", country, bankId)
+}
+
+func normalizeBankID(bankId string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(bankId))
+}
+
+func normalizeBic(bic string) string {
+	bic = strings.ToUpper(strings.TrimSpace(bic))
+
+	if len(bic) == 11 && strings.HasSuffix(bic, "XXX") {
+		return bic[:8]
+	}
+
+	return bic
+}
@@ -0,0 +1,97 @@
+//go:build unit
+
+package bankdirectory_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/bankdirectory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirectory(t *testing.T) {
+	t.Run("should resolve a sort code to its institution", func(t *testing.T) {
+		t.Parallel()
+
+		directory, error := bankdirectory.LoadFile("testdata/institutions.csv")
+
+		assert.Nil(t, error)
+		assert.Equal(t, 4, directory.Len())
+
+		institution, ok := directory.LookupBankID("gb", "40-03-00")
+
+		assert.True(t, ok)
+		assert.Equal(t, bankdirectory.Institution{
+			Country:    "GB",
+			BankIDCode: "GBDSC",
+			BankID:     "400300",
+			Bic:        "NWBKGB22",
+			Name:       "National Westminster Bank",
+			Schemes:    []string{"FPS", "BACS", "CHAPS"},
+		}, institution)
+		assert.True(t, institution.Reaches("fps"))
+		assert.False(t, institution.Reaches("SEPA"))
+
+		_, ok = directory.LookupBankID("DE", "400300")
+
+		assert.False(t, ok)
+	})
+
+	t.Run("should resolve a BLZ and build an account with it", func(t *testing.T) {
+		t.Parallel()
+
+		directory, _ := bankdirectory.LoadFile("testdata/institutions.csv")
+		institution, ok := directory.LookupBankID("DE", "100 200 30")
+
+		assert.True(t, ok)
+		assert.Equal(t, "UniCredit Bank", institution.Name)
+
+		account, error := institution.Apply(form3.NewGermanAccount("743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb")).WithNames("Samantha Holder").Build()
+
+		assert.Nil(t, error)
+		assert.Equal(t, "10020030", account.Data.Attributes.BankID)
+		assert.Equal(t, "HYVEDEMM488", account.Data.Attributes.Bic)
+		assert.Equal(t, "DEBLZ", account.Data.Attributes.BankIDCode)
+	})
+
+	t.Run("should resolve a BIC with or without the primary office branch code", func(t *testing.T) {
+		t.Parallel()
+
+		directory, _ := bankdirectory.LoadFile("testdata/institutions.csv")
+
+		assert.Len(t, directory.LookupBic("NWBKGB22XXX"), 2)
+		assert.Len(t, directory.LookupBic("barcgb22"), 1)
+		assert.Empty(t, directory.LookupBic("HYVEDEMM"))
+		assert.Len(t, directory.LookupBic("HYVEDEMM488"), 1)
+	})
+
+	t.Run("should replace an institution with the same bank ID", func(t *testing.T) {
+		t.Parallel()
+
+		directory := bankdirectory.New(bankdirectory.Institution{Country: "GB", BankID: "400300", Bic: "NWBKGB22", Name: "NatWest"})
+		directory.Add(bankdirectory.Institution{Country: "GB", BankID: "400300", Bic: "RBOSGB2L", Name: "Royal Bank of Scotland"})
+
+		institution, _ := directory.LookupBankID("GB", "400300")
+
+		assert.Equal(t, 1, directory.Len())
+		assert.Equal(t, "Royal Bank of Scotland", institution.Name)
+		assert.Empty(t, directory.LookupBic("NWBKGB22"))
+		assert.Len(t, directory.LookupBic("RBOSGB2L"), 1)
+	})
+
+	t.Run("should not load a dataset without the required columns", func(t *testing.T) {
+		t.Parallel()
+
+		directory, error := bankdirectory.Load(strings.NewReader("country,bank_id,bic\nGB,400300,NWBKGB22\n"))
+
+		assert.Nil(t, directory)
+		assert.EqualError(t, error, "dataset header is missing the bank_id_code column")
+
+		directory, error = bankdirectory.Load(strings.NewReader(""))
+
+		assert.Nil(t, directory)
+		assert.EqualError(t, error, "dataset header could not be read: EOF")
+	})
+}
@@ -0,0 +1,186 @@
+package form3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// DefaultCreateManyConcurrency is the default number of accounts being created at the same time.
+const DefaultCreateManyConcurrency = 8
+
+// OnCheckpoint defines the function interface that is called when the checkpoint of a bulk operation advances.
+type OnCheckpoint func(checkpoint int)
+
+// CreateManyOptions allows one to customize how accounts are created in bulk.
+type CreateManyOptions struct {
+	Concurrency       int          // How many accounts can be created at the same time. DefaultCreateManyConcurrency is used if not positive.
+	RequestsPerSecond float64      // How many accounts can be created per second. There is no limit if not positive.
+	Checkpoint        int          // Index of the first account to be created, previous accounts are skipped. Allows resuming a previous operation.
+	OnCheckpoint      OnCheckpoint // Called whenever all accounts before the checkpoint were created, can be used to store progress.
+}
+
+// CreateManyResult contains the outcome of creating a single account in bulk.
+type CreateManyResult struct {
+	Index    int           // Position of the account in the input.
+	Account  *Account      // Created account, if successful.
+	Response *ResponseMeta // Http response, if the http request was performed.
+	Error    error         // Reason why the account was not created.
+	Skipped  bool          // If the account was skipped because it is before the checkpoint.
+}
+
+// BulkOperationError is returned when some items of a bulk operation were not successful.
+type BulkOperationError struct {
+	Message    string // Contains customized message with the number of failures.
+	Failed     []int  // Contains the indexes of the items that were not successful, in input order.
+	Checkpoint int    // Contains the index from where the operation can be resumed, which is never after the first failed item.
+}
+
+// Error returns the message.
+func (e BulkOperationError) Error() string {
+	return e.Message
+}
+
+// CreateMany allows one to create many FORM3 accounts at the same time.
+//
+// A result is returned for every account, in input order, even if some accounts could not be created.
+// If any account was not created a BulkOperationError is returned with the failed indexes.
+//
+// The operation can be resumed using the checkpoint, every account before it was created. It stops at the first account
+// that was not created, so that resuming retries it. Since accounts are created concurrently some accounts after the
+// checkpoint may have been created already.
+func (s *AccountService) CreateMany(ctx context.Context, accounts []*Account, options CreateManyOptions) ([]CreateManyResult, error) {
+	concurrency := options.Concurrency
+
+	if concurrency <= 0 {
+		concurrency = DefaultCreateManyConcurrency
+	}
+
+	results := make([]CreateManyResult, len(accounts))
+
+	for index := range results {
+		results[index].Index = index
+		results[index].Skipped = index < options.Checkpoint
+	}
+
+	tracker := newCheckpointTracker(len(accounts), options.Checkpoint, options.OnCheckpoint)
+	indexes := make(chan int)
+	waitGroup := sync.WaitGroup{}
+
+	for worker := 0; worker < concurrency; worker++ {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			for index := range indexes {
+				account, response, error := s.CreateWithContext(ctx, accounts[index])
+
+				results[index].Account = account
+				results[index].Response = response
+				results[index].Error = error
+
+				if error == nil {
+					tracker.created(index)
+				}
+			}
+		}()
+	}
+
+	var limiter *RateLimiter
+
+	if options.RequestsPerSecond > 0 {
+		limiter = NewRateLimiter(RateLimit{RequestsPerSecond: options.RequestsPerSecond, Burst: 1}, RateLimitWait).WithClock(s.Client.clock)
+	}
+
+	next := tracker.checkpoint
+	var stopped error
+
+dispatch:
+	for ; next < len(accounts); next++ {
+		if limiter != nil {
+			stopped = limiter.Wait(ctx, http.MethodPost)
+
+			if stopped != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			stopped = OperationError{Message: ctx.Err().Error()}
+
+			break dispatch
+		case indexes <- next:
+		}
+	}
+
+	close(indexes)
+	waitGroup.Wait()
+
+	for ; next < len(accounts); next++ {
+		results[next].Error = stopped
+	}
+
+	failed := []int{}
+
+	for _, result := range results {
+		if result.Error != nil {
+			failed = append(failed, result.Index)
+		}
+	}
+
+	if len(failed) > 0 {
+		return results, BulkOperationError{
+			Message:    fmt.Sprintf("%d of %d accounts were not created", len(failed), len(accounts)),
+			Failed:     failed,
+			Checkpoint: tracker.current(),
+		}
+	}
+
+	return results, nil
+}
+
+// checkpointTracker keeps the index before which every item of a bulk operation was successful.
+type checkpointTracker struct {
+	mutex        sync.Mutex
+	done         []bool
+	checkpoint   int
+	onCheckpoint OnCheckpoint
+}
+
+func newCheckpointTracker(total int, checkpoint int, onCheckpoint OnCheckpoint) *checkpointTracker {
+	if checkpoint < 0 {
+		checkpoint = 0
+	}
+
+	if checkpoint > total {
+		checkpoint = total
+	}
+
+	return &checkpointTracker{done: make([]bool, total), checkpoint: checkpoint, onCheckpoint: onCheckpoint}
+}
+
+func (t *checkpointTracker) created(index int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.done[index] = true
+	previous := t.checkpoint
+
+	for t.checkpoint < len(t.done) && t.done[t.checkpoint] {
+		t.checkpoint++
+	}
+
+	if t.checkpoint != previous && t.onCheckpoint != nil {
+		t.onCheckpoint(t.checkpoint)
+	}
+}
+
+func (t *checkpointTracker) current() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.checkpoint
+}
@@ -0,0 +1,186 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBulkAccounts(total int) []*form3.Account {
+	accounts := []*form3.Account{}
+
+	for index := 0; index < total; index++ {
+		accounts = append(accounts, &form3.Account{Data: &form3.AccountData{ID: fmt.Sprintf("account-%d", index)}})
+	}
+
+	return accounts
+}
+
+// newBulkServer creates a server that creates accounts, failing to create the one with the given ID the first time it is sent.
+func newBulkServer(t *testing.T, failedID string, options ...form3.Option) (*form3.Client, *sync.Map) {
+	created := &sync.Map{}
+	sent := &sync.Map{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account := &form3.Account{}
+		json.NewDecoder(r.Body).Decode(account)
+
+		if _, resent := sent.LoadOrStore(account.Data.ID, true); account.Data.ID == failedID && !resent {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("{\"error_message\":\"invalid account\"}"))
+
+			return
+		}
+
+		created.Store(account.Data.ID, true)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(account)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return newTestClient(t, server.URL, options...), created
+}
+
+func TestAccounts_CreateMany(t *testing.T) {
+	t.Run("should create all accounts and return the results in input order", func(t *testing.T) {
+		t.Parallel()
+
+		client, created := newBulkServer(t, "")
+		accounts := newBulkAccounts(50)
+		checkpoints := []int{}
+
+		results, error := client.Accounts.CreateMany(context.Background(), accounts, form3.CreateManyOptions{
+			Concurrency:  4,
+			OnCheckpoint: func(checkpoint int) { checkpoints = append(checkpoints, checkpoint) },
+		})
+
+		assert.Nil(t, error)
+		assert.Len(t, results, 50)
+
+		for index, result := range results {
+			assert.Equal(t, index, result.Index)
+			assert.Equal(t, accounts[index], result.Account)
+			assert.Equal(t, 201, result.Response.StatusCode)
+			assert.Nil(t, result.Error)
+
+			_, ok := created.Load(accounts[index].Data.ID)
+			assert.True(t, ok)
+		}
+
+		assert.Equal(t, 50, checkpoints[len(checkpoints)-1])
+	})
+
+	t.Run("should report the accounts that were not created", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newBulkServer(t, "account-3")
+
+		results, error := client.Accounts.CreateMany(context.Background(), newBulkAccounts(10), form3.CreateManyOptions{Concurrency: 3})
+
+		assert.Equal(t, form3.BulkOperationError{Message: "1 of 10 accounts were not created", Failed: []int{3}, Checkpoint: 3}, error)
+		assert.Equal(t, form3.OperationError{Message: "400 Bad Request", Body: []byte("{\"error_message\":\"invalid account\"}")}, results[3].Error)
+		assert.Nil(t, results[3].Account)
+		assert.Nil(t, results[4].Error)
+	})
+
+	t.Run("should create the accounts that were not created when resuming from the checkpoint", func(t *testing.T) {
+		t.Parallel()
+
+		client, created := newBulkServer(t, "account-3")
+		accounts := newBulkAccounts(10)
+
+		_, error := client.Accounts.CreateMany(context.Background(), accounts, form3.CreateManyOptions{Concurrency: 3})
+
+		assert.IsType(t, form3.BulkOperationError{}, error)
+
+		_, ok := created.Load("account-3")
+		assert.False(t, ok)
+
+		results, error := client.Accounts.CreateMany(context.Background(), accounts, form3.CreateManyOptions{Checkpoint: error.(form3.BulkOperationError).Checkpoint})
+
+		assert.Nil(t, error)
+		assert.True(t, results[2].Skipped)
+		assert.False(t, results[3].Skipped)
+
+		_, ok = created.Load("account-3")
+		assert.True(t, ok)
+	})
+
+	t.Run("should skip the accounts before the checkpoint when resuming", func(t *testing.T) {
+		t.Parallel()
+
+		client, created := newBulkServer(t, "")
+		accounts := newBulkAccounts(10)
+
+		results, error := client.Accounts.CreateMany(context.Background(), accounts, form3.CreateManyOptions{Checkpoint: 6, RequestsPerSecond: 1000})
+
+		assert.Nil(t, error)
+
+		for index, result := range results {
+			_, ok := created.Load(accounts[index].Data.ID)
+
+			assert.Equal(t, index < 6, result.Skipped)
+			assert.Equal(t, index >= 6, ok)
+		}
+	})
+
+	t.Run("should not create accounts once the context is done", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newBulkServer(t, "")
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		results, error := client.Accounts.CreateMany(ctx, newBulkAccounts(5), form3.CreateManyOptions{Concurrency: 1})
+
+		assert.IsType(t, form3.BulkOperationError{}, error)
+		assert.Len(t, error.(form3.BulkOperationError).Failed, 5)
+
+		for _, result := range results {
+			assert.Contains(t, result.Error.Error(), "context canceled")
+		}
+	})
+
+	t.Run("should wait for the rate limit using the client clock", func(t *testing.T) {
+		t.Parallel()
+
+		clock := form3test.NewFakeClock(time.Now()).WithAutoAdvance()
+		client, _ := newBulkServer(t, "", form3.WithClock(clock))
+
+		_, error := client.Accounts.CreateMany(context.Background(), newBulkAccounts(3), form3.CreateManyOptions{Concurrency: 1, RequestsPerSecond: 1})
+
+		assert.Nil(t, error)
+		assert.Equal(t, []time.Duration{time.Second, time.Second}, clock.Waits())
+	})
+
+	t.Run("should not create accounts that cannot be rate limited before the deadline", func(t *testing.T) {
+		t.Parallel()
+
+		client, created := newBulkServer(t, "")
+		accounts := newBulkAccounts(3)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		results, error := client.Accounts.CreateMany(ctx, accounts, form3.CreateManyOptions{RequestsPerSecond: 0.1})
+
+		assert.Equal(t, form3.BulkOperationError{Message: "2 of 3 accounts were not created", Failed: []int{1, 2}, Checkpoint: 1}, error)
+		assert.Nil(t, results[0].Error)
+		assert.Contains(t, results[1].Error.Error(), "rate limit exceeded")
+		assert.Equal(t, results[1].Error, results[2].Error)
+
+		_, ok := created.Load(accounts[1].Data.ID)
+		assert.False(t, ok)
+	})
+}
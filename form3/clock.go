@@ -0,0 +1,24 @@
+package form3
+
+import "time"
+
+// Clock defines how time is told and how time is waited for.
+//
+// It allows time to be controlled, for example while testing retries.
+type Clock interface {
+	Now() time.Time                         // Now returns the current time.
+	After(d time.Duration) <-chan time.Time // After waits for the duration to elapse and then sends the current time on the returned channel.
+}
+
+// systemClock uses the time of the operating system.
+type systemClock struct{}
+
+// Now returns the current time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After waits for the duration to elapse and then sends the current time on the returned channel.
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
@@ -0,0 +1,154 @@
+package form3
+
+import "context"
+
+// confirmationOfPayeeUri contains the path to the name verification resources.
+const confirmationOfPayeeUri string = "/v1/confirmation-of-payee/name-verifications"
+
+// nameVerificationType is the JSON:API type of a name verification.
+const nameVerificationType string = "name_verifications"
+
+const (
+	AccountTypePersonal = "personal" // AccountTypePersonal is used by accounts held by a person.
+	AccountTypeBusiness = "business" // AccountTypeBusiness is used by accounts held by a business.
+)
+
+// NameMatch is the outcome of checking the name of an account holder.
+type NameMatch string
+
+const (
+	NameMatchFull                NameMatch = "full_match"            // NameMatchFull means the name matches the account holder name.
+	NameMatchClose               NameMatch = "close_match"           // NameMatchClose means the name is similar to the account holder name, which is suggested.
+	NameMatchNone                NameMatch = "no_match"              // NameMatchNone means the name does not match or the account could not be checked.
+	NameMatchAccountTypeMismatch NameMatch = "account_type_mismatch" // NameMatchAccountTypeMismatch means the name matches but the account is not of the requested type.
+)
+
+// Reason codes returned by the responding bank when the name does not fully match.
+//
+// More details available in: https://www.wearepay.uk/what-we-do/overlay-services/confirmation-of-payee/
+const (
+	ReasonCodeNameNotMatched             = "ANNM" // ReasonCodeNameNotMatched means the name does not match.
+	ReasonCodeMayBeMatch                 = "MBAM" // ReasonCodeMayBeMatch means the name is similar to the account holder name.
+	ReasonCodeBusinessNameMatched        = "BANM" // ReasonCodeBusinessNameMatched means the name matches a business account, but a personal account was requested.
+	ReasonCodePersonalNameMatched        = "PANM" // ReasonCodePersonalNameMatched means the name matches a personal account, but a business account was requested.
+	ReasonCodeBusinessNameMayBeMatch     = "BAMM" // ReasonCodeBusinessNameMayBeMatch means the name is similar to a business account, but a personal account was requested.
+	ReasonCodePersonalNameMayBeMatch     = "PAMM" // ReasonCodePersonalNameMayBeMatch means the name is similar to a personal account, but a business account was requested.
+	ReasonCodeAccountDoesNotExist        = "AC01" // ReasonCodeAccountDoesNotExist means the account number is not valid or does not exist.
+	ReasonCodeAccountNotSupported        = "ACNS" // ReasonCodeAccountNotSupported means the account cannot be checked.
+	ReasonCodeOptedOut                   = "OPTO" // ReasonCodeOptedOut means the account holder opted out of confirmation of payee.
+	ReasonCodeAccountSwitched            = "CASS" // ReasonCodeAccountSwitched means the account was switched to another bank.
+	ReasonCodeSortCodeNotSupported       = "SCNS" // ReasonCodeSortCodeNotSupported means the bank of the sort code does not support confirmation of payee.
+	ReasonCodeSecondaryReferenceNotValid = "IVCR" // ReasonCodeSecondaryReferenceNotValid means the secondary identification is not valid.
+)
+
+// ConfirmationOfPayeeService allows one to check the name of an account holder before sending a payment.
+//
+// More details available in: https://www.api-docs.form3.tech/api/schemes/confirmation-of-payee
+type ConfirmationOfPayeeService struct {
+	*ImmutableResourceService[NameVerificationData]
+}
+
+// NameVerificationRequest contains the details of the account whose holder name is checked.
+type NameVerificationRequest struct {
+	OrganisationID          string // ID of the organisation performing the check.
+	SortCode                string // Sort code of the account.
+	AccountNumber           string // Account number of the account.
+	Name                    string // Name the account holder is expected to have.
+	AccountType             string // AccountTypePersonal or AccountTypeBusiness.
+	SecondaryIdentification string // Secondary identification, for example a building society roll number, if any.
+}
+
+// NameVerificationResult contains the outcome of checking the name of an account holder.
+type NameVerificationResult struct {
+	Match         NameMatch         // Outcome of the check.
+	ReasonCode    string            // Reason given by the responding bank when the name does not fully match.
+	SuggestedName string            // Name of the account holder, suggested when the name is a close match or the account type does not match.
+	Verification  *NameVerification // Name verification returned by the server.
+}
+
+// Represents a FORM3 name verification.
+type NameVerification = Resource[NameVerificationData]
+
+// Represents a FORM3 name verification data.
+type NameVerificationData struct {
+	Attributes *NameVerificationAttributes `json:"attributes,omitempty"`
+	OrganisationResourceFields
+}
+
+// Represents a FORM3 name verification attributes.
+//
+// The account details and the name are sent by the client, the match details are returned by the server.
+type NameVerificationAttributes struct {
+	AccountNumber           string `json:"account_number,omitempty"`
+	AccountType             string `json:"account_type,omitempty"`
+	ActualName              string `json:"actual_name,omitempty"`
+	BankID                  string `json:"bank_id,omitempty"`
+	BankIDCode              string `json:"bank_id_code,omitempty"`
+	Matched                 bool   `json:"matched,omitempty"`
+	Name                    string `json:"name,omitempty"`
+	ReasonCode              string `json:"reason_code,omitempty"`
+	SecondaryIdentification string `json:"secondary_identification,omitempty"`
+}
+
+// Verify allows one to check if a name matches the holder of an account.
+func (s *ConfirmationOfPayeeService) Verify(request NameVerificationRequest) (*NameVerificationResult, *ResponseMeta, error) {
+	return s.VerifyWithContext(context.Background(), request)
+}
+
+// VerifyWithContext is like Verify but the request is cancelled once the provided context is done.
+func (s *ConfirmationOfPayeeService) VerifyWithContext(ctx context.Context, request NameVerificationRequest) (*NameVerificationResult, *ResponseMeta, error) {
+	if request.SortCode == "" || request.AccountNumber == "" || request.Name == "" {
+		return nil, nil, ValidationError{Field: "request", Message: "must have a sort code, an account number and a name"}
+	}
+
+	id, error := newUuid()
+
+	if error != nil {
+		return nil, nil, OperationError{Message: error.Error()}
+	}
+
+	verification, response, error := s.CreateWithContext(ctx, &NameVerification{
+		Data: &NameVerificationData{
+			OrganisationResourceFields: OrganisationResourceFields{ID: id, OrganisationID: request.OrganisationID, Type: nameVerificationType},
+			Attributes: &NameVerificationAttributes{
+				AccountNumber:           request.AccountNumber,
+				AccountType:             request.AccountType,
+				BankID:                  request.SortCode,
+				BankIDCode:              ukScheme.bankIDCode,
+				Name:                    request.Name,
+				SecondaryIdentification: request.SecondaryIdentification,
+			},
+		},
+	})
+
+	if error != nil {
+		return nil, response, error
+	}
+
+	if verification.Data == nil || verification.Data.Attributes == nil {
+		return nil, response, OperationError{Message: "name verification data is missing"}
+	}
+
+	return newNameVerificationResult(verification), response, nil
+}
+
+// newNameVerificationResult finds the outcome of a name verification using its reason code.
+func newNameVerificationResult(verification *NameVerification) *NameVerificationResult {
+	attributes := verification.Data.Attributes
+	result := &NameVerificationResult{ReasonCode: attributes.ReasonCode, Verification: verification}
+
+	switch {
+	case attributes.Matched:
+		result.Match = NameMatchFull
+	case attributes.ReasonCode == ReasonCodeMayBeMatch || attributes.ReasonCode == ReasonCodeBusinessNameMayBeMatch || attributes.ReasonCode == ReasonCodePersonalNameMayBeMatch:
+		result.Match = NameMatchClose
+		result.SuggestedName = attributes.ActualName
+	case attributes.ReasonCode == ReasonCodeBusinessNameMatched || attributes.ReasonCode == ReasonCodePersonalNameMatched:
+		result.Match = NameMatchAccountTypeMismatch
+		result.SuggestedName = attributes.ActualName
+	default:
+		result.Match = NameMatchNone
+	}
+
+	return result
+}
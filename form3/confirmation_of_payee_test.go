@@ -0,0 +1,104 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirmationOfPayee_Verify(t *testing.T) {
+	request := form3.NameVerificationRequest{
+		OrganisationID: "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb",
+		SortCode:       "400300",
+		AccountNumber:  "41426819",
+		Name:           "Samantha Holder",
+		AccountType:    form3.AccountTypePersonal,
+	}
+
+	tests := []struct {
+		description string
+		attributes  string
+		expected    form3.NameMatch
+		reasonCode  string
+		suggested   string
+	}{
+		{description: "should return a full match", attributes: `{"matched":true}`, expected: form3.NameMatchFull},
+		{description: "should return a close match with the suggested name", attributes: `{"reason_code":"MBAM","actual_name":"Samantha Holden"}`, expected: form3.NameMatchClose, reasonCode: "MBAM", suggested: "Samantha Holden"},
+		{description: "should return a close match when the account type does not match either", attributes: `{"reason_code":"BAMM","actual_name":"Samantha Holden Ltd"}`, expected: form3.NameMatchClose, reasonCode: "BAMM", suggested: "Samantha Holden Ltd"},
+		{description: "should return an account type mismatch", attributes: `{"reason_code":"BANM","actual_name":"Samantha Holder"}`, expected: form3.NameMatchAccountTypeMismatch, reasonCode: "BANM", suggested: "Samantha Holder"},
+		{description: "should return no match", attributes: `{"reason_code":"ANNM"}`, expected: form3.NameMatchNone, reasonCode: "ANNM"},
+		{description: "should return no match when the account cannot be checked", attributes: `{"reason_code":"OPTO","actual_name":"Samantha Holder"}`, expected: form3.NameMatchNone, reasonCode: "OPTO"},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			server, received, body := newResourceServer(t, 201, `{"data":{"id":"n1","attributes":`+test.attributes+`}}`)
+			client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+
+			result, response, error := client.ConfirmationOfPayee.Verify(request)
+
+			sent := form3.NameVerification{}
+			_ = json.Unmarshal(*body, &sent)
+
+			assert.Nil(t, error)
+			assert.Equal(t, 201, response.StatusCode)
+			assert.Equal(t, "/v1/confirmation-of-payee/name-verifications", received.URL.Path)
+			assert.Equal(t, &form3.NameVerificationAttributes{AccountNumber: "41426819", AccountType: "personal", BankID: "400300", BankIDCode: "GBDSC", Name: "Samantha Holder"}, sent.Data.Attributes)
+			assert.Equal(t, "name_verifications", sent.Data.Type)
+			assert.Equal(t, test.expected, result.Match)
+			assert.Equal(t, test.reasonCode, result.ReasonCode)
+			assert.Equal(t, test.suggested, result.SuggestedName)
+			assert.Equal(t, "n1", result.Verification.Data.ID)
+		})
+	}
+
+	t.Run("should not verify a name without the account details", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := form3.New()
+
+		result, response, error := client.ConfirmationOfPayee.Verify(form3.NameVerificationRequest{Name: "Samantha Holder"})
+
+		assert.Nil(t, result)
+		assert.Nil(t, response)
+		assert.Equal(t, form3.ValidationError{Field: "request", Message: "must have a sort code, an account number and a name"}, error)
+	})
+
+	t.Run("should return an error when the server does not return the verification", func(t *testing.T) {
+		t.Parallel()
+
+		server, _, _ := newResourceServer(t, 201, `{}`)
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+
+		result, _, error := client.ConfirmationOfPayee.Verify(request)
+
+		assert.Nil(t, result)
+		assert.Equal(t, form3.OperationError{Message: "name verification data is missing"}, error)
+	})
+
+	t.Run("should only allow name verifications to be created, fetched and listed", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := form3.New()
+		service := reflect.TypeOf(client.ConfirmationOfPayee)
+
+		for _, method := range []string{"Create", "Fetch", "List", "Verify"} {
+			_, ok := service.MethodByName(method)
+			assert.True(t, ok, method)
+		}
+
+		for _, method := range []string{"Update", "UpdateWithRetry", "Delete", "DeleteLatest"} {
+			_, ok := service.MethodByName(method)
+			assert.False(t, ok, method)
+		}
+	})
+}
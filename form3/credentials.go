@@ -0,0 +1,173 @@
+package form3
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+// publicKeyType is the JSON:API type of a public key.
+const publicKeyType string = "public_keys"
+
+// rsaKeySize is the size in bits of generated RSA keys.
+const rsaKeySize = 2048
+
+// KeyAlgorithm is the algorithm of a generated key pair.
+type KeyAlgorithm string
+
+const (
+	KeyAlgorithmRsa   KeyAlgorithm = "rsa"   // KeyAlgorithmRsa generates 2048 bit RSA key pairs.
+	KeyAlgorithmEcdsa KeyAlgorithm = "ecdsa" // KeyAlgorithmEcdsa generates ECDSA key pairs using the P-256 curve.
+)
+
+// CredentialService allows access to operations related to the public keys of users, used to verify signed requests.
+//
+// Key pairs are generated locally, only the public key is uploaded. Public keys can be uploaded, listed and revoked.
+//
+// More details available in: https://www.api-docs.form3.tech/api/platform/security/credentials
+type CredentialService struct {
+	Client *Client
+}
+
+// Credential is a key pair whose public key was uploaded for a user.
+type Credential struct {
+	UserID      string        // ID of the user the public key belongs to.
+	PublicKeyID string        // ID of the uploaded public key, used as the key ID of signatures.
+	PrivateKey  crypto.Signer // Private key used to sign requests, must be kept secret.
+}
+
+// Represents a FORM3 public key.
+type PublicKey = Resource[PublicKeyData]
+
+// Represents a FORM3 public key data.
+type PublicKeyData struct {
+	Attributes *PublicKeyAttributes `json:"attributes,omitempty"`
+	ResourceFields
+}
+
+// Represents a FORM3 public key attributes.
+//
+// The public key is PEM encoded.
+type PublicKeyAttributes struct {
+	PublicKey string `json:"public_key,omitempty"`
+}
+
+// GenerateKey generates a private key locally, whose public key can be uploaded.
+func GenerateKey(algorithm KeyAlgorithm) (crypto.Signer, error) {
+	switch algorithm {
+	case KeyAlgorithmRsa:
+		return rsa.GenerateKey(rand.Reader, rsaKeySize)
+	case KeyAlgorithmEcdsa:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, ValidationError{Field: "algorithm", Message: "must be rsa or ecdsa"}
+	}
+}
+
+// EncodePublicKey returns the PEM encoded public key of a private key, as it is uploaded.
+func EncodePublicKey(privateKey crypto.Signer) (string, error) {
+	encoded, error := x509.MarshalPKIXPublicKey(privateKey.Public())
+
+	if error != nil {
+		return "", OperationError{Message: error.Error()}
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: encoded})), nil
+}
+
+// Signer creates a signer using the private key of the credential, to be used with WithSigner.
+func (c *Credential) Signer() (*Signer, error) {
+	return NewSigner(c.PublicKeyID, c.PrivateKey)
+}
+
+// PublicKeys allows access to the public keys of a user.
+func (s *CredentialService) PublicKeys(userId string) *ResourceService[PublicKeyData] {
+	return NewResourceService[PublicKeyData](s.Client, fmt.Sprintf("%s/%s/credentials/public_key", usersUri, userId), "public key")
+}
+
+// Upload allows one to upload a PEM encoded public key for a user.
+//
+// A random UUID is used as the public key ID.
+func (s *CredentialService) Upload(userId string, publicKey string) (*PublicKey, *ResponseMeta, error) {
+	return s.UploadWithContext(context.Background(), userId, publicKey)
+}
+
+// UploadWithContext is like Upload but the request is cancelled once the provided context is done.
+func (s *CredentialService) UploadWithContext(ctx context.Context, userId string, publicKey string) (*PublicKey, *ResponseMeta, error) {
+	if block, _ := pem.Decode([]byte(publicKey)); block == nil || block.Type != "PUBLIC KEY" {
+		return nil, nil, ValidationError{Field: "public_key", Message: "must be a PEM encoded public key"}
+	}
+
+	id, error := newUuid()
+
+	if error != nil {
+		return nil, nil, OperationError{Message: error.Error()}
+	}
+
+	return s.PublicKeys(userId).CreateWithContext(ctx, &PublicKey{
+		Data: &PublicKeyData{ResourceFields: ResourceFields{ID: id, Type: publicKeyType}, Attributes: &PublicKeyAttributes{PublicKey: publicKey}},
+	})
+}
+
+// List allows one to list a page of the public keys of a user.
+func (s *CredentialService) List(userId string, options ListOptions) (*ResourceList[PublicKeyData], *ResponseMeta, error) {
+	return s.ListWithContext(context.Background(), userId, options)
+}
+
+// ListWithContext is like List but the request is cancelled once the provided context is done.
+func (s *CredentialService) ListWithContext(ctx context.Context, userId string, options ListOptions) (*ResourceList[PublicKeyData], *ResponseMeta, error) {
+	return s.PublicKeys(userId).ListWithContext(ctx, options)
+}
+
+// Revoke allows one to delete a public key of a user, so that requests signed with its private key are rejected.
+func (s *CredentialService) Revoke(userId string, publicKeyId string) (*ResponseMeta, error) {
+	return s.RevokeWithContext(context.Background(), userId, publicKeyId)
+}
+
+// RevokeWithContext is like Revoke but the requests are cancelled once the provided context is done.
+func (s *CredentialService) RevokeWithContext(ctx context.Context, userId string, publicKeyId string) (*ResponseMeta, error) {
+	return s.PublicKeys(userId).DeleteLatestWithContext(ctx, publicKeyId)
+}
+
+// Register allows one to generate a key pair locally and upload its public key for a user.
+//
+// The returned credential can be used to sign the requests of a new client:
+//
+//	signer, error := credential.Signer()
+//	client, error := form3.New(form3.WithSigner(signer))
+func (s *CredentialService) Register(userId string, algorithm KeyAlgorithm) (*Credential, *ResponseMeta, error) {
+	return s.RegisterWithContext(context.Background(), userId, algorithm)
+}
+
+// RegisterWithContext is like Register but the request is cancelled once the provided context is done.
+func (s *CredentialService) RegisterWithContext(ctx context.Context, userId string, algorithm KeyAlgorithm) (*Credential, *ResponseMeta, error) {
+	privateKey, error := GenerateKey(algorithm)
+
+	if error != nil {
+		return nil, nil, error
+	}
+
+	publicKey, error := EncodePublicKey(privateKey)
+
+	if error != nil {
+		return nil, nil, error
+	}
+
+	uploaded, response, error := s.UploadWithContext(ctx, userId, publicKey)
+
+	if error != nil {
+		return nil, response, error
+	}
+
+	if uploaded.Data == nil {
+		return nil, response, OperationError{Message: "public key data is missing"}
+	}
+
+	return &Credential{UserID: userId, PublicKeyID: uploaded.Data.ID, PrivateKey: privateKey}, response, nil
+}
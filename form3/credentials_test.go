@@ -0,0 +1,188 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
+	"github.com/stretchr/testify/assert"
+)
+
+const credentialUserID = "7b8c9d0e-1f2a-4b3c-9d4e-5f6a7b8c9d0e"
+
+var authorizationFormat = regexp.MustCompile(`^Signature keyId="([^"]+)",algorithm="([^"]+)",headers="([^"]+)",signature="([^"]+)"$`)
+
+// verifySignature checks the signature of a request using a PEM encoded public key, returning the key ID.
+func verifySignature(t *testing.T, request *http.Request, publicKeyPem string) string {
+	parts := authorizationFormat.FindStringSubmatch(request.Header.Get("Authorization"))
+
+	if !assert.Len(t, parts, 5) {
+		return ""
+	}
+
+	block, _ := pem.Decode([]byte(publicKeyPem))
+	publicKey, error := x509.ParsePKIXPublicKey(block.Bytes)
+
+	assert.Nil(t, error)
+
+	signature, _ := base64.StdEncoding.DecodeString(parts[4])
+	hashed := sha256.Sum256([]byte(form3.SigningString(request, strings.Split(parts[3], " "))))
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		assert.Equal(t, form3.SignatureAlgorithmRsa, parts[2])
+		assert.Nil(t, rsa.VerifyPKCS1v15(key, crypto.SHA256, hashed[:], signature))
+	case *ecdsa.PublicKey:
+		assert.Equal(t, form3.SignatureAlgorithmEcdsa, parts[2])
+		assert.True(t, ecdsa.VerifyASN1(key, hashed[:], signature))
+	}
+
+	return parts[1]
+}
+
+func TestCredentials(t *testing.T) {
+	for _, algorithm := range []form3.KeyAlgorithm{form3.KeyAlgorithmRsa, form3.KeyAlgorithmEcdsa} {
+		algorithm := algorithm
+
+		t.Run("should register a "+string(algorithm)+" key and sign requests with it", func(t *testing.T) {
+			t.Parallel()
+
+			uploaded := form3.PublicKeyData{}
+			signed := []*http.Request{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				if r.URL.Path == "/v1/security/users/"+credentialUserID+"/credentials/public_key" {
+					document := form3.PublicKey{}
+					_ = json.Unmarshal(body, &document)
+					uploaded = *document.Data
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write(body)
+
+					return
+				}
+
+				digest := sha256.Sum256(body)
+				assert.Equal(t, "SHA-256="+base64.StdEncoding.EncodeToString(digest[:]), r.Header.Get("Digest"))
+				signed = append(signed, r.Clone(r.Context()))
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"data":{"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"}}`))
+			}))
+			defer server.Close()
+			serverUrl, _ := url.Parse(server.URL)
+			client, _ := form3.New(form3.WithBaseUrl(serverUrl))
+
+			credential, response, error := client.Credentials.Register(credentialUserID, algorithm)
+
+			assert.Nil(t, error)
+			assert.Equal(t, 201, response.StatusCode)
+			assert.Equal(t, credentialUserID, credential.UserID)
+			assert.Equal(t, uploaded.ID, credential.PublicKeyID)
+			assert.Equal(t, "public_keys", uploaded.Type)
+
+			signer, error := credential.Signer()
+
+			assert.Nil(t, error)
+			assert.Equal(t, credential.PublicKeyID, signer.KeyID())
+
+			clock := form3test.NewFakeClock(time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC))
+			signingClient, _ := form3.New(form3.WithBaseUrl(serverUrl), form3.WithSigner(signer), form3.WithClock(clock))
+			_, _, error = signingClient.Accounts.Create(&form3.Account{Data: &form3.AccountData{ID: "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", Type: "accounts"}})
+
+			assert.Nil(t, error)
+			assert.Len(t, signed, 1)
+			assert.Equal(t, "Wed, 01 Mar 2023 10:00:00 GMT", signed[0].Header.Get("Date"))
+			assert.Equal(t, credential.PublicKeyID, verifySignature(t, signed[0], uploaded.Attributes.PublicKey))
+		})
+	}
+
+	t.Run("should sign requests once the rate limiter allows them", func(t *testing.T) {
+		t.Parallel()
+
+		dates := []string{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			dates = append(dates, r.Header.Get("Date"))
+			_, _ = w.Write([]byte(`{"data":{"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"}}`))
+		}))
+		defer server.Close()
+		serverUrl, _ := url.Parse(server.URL)
+		privateKey, _ := form3.GenerateKey(form3.KeyAlgorithmEcdsa)
+		signer, _ := form3.NewSigner("c1f5a3b2-7d4e-4f6a-9b8c-0d1e2f3a4b5c", privateKey)
+		clock := form3test.NewFakeClock(time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)).WithAutoAdvance()
+		limiter := form3.NewRateLimiter(form3.RateLimit{RequestsPerSecond: 0.5, Burst: 1}, form3.RateLimitWait).WithClock(clock)
+		client, _ := form3.New(form3.WithBaseUrl(serverUrl), form3.WithSigner(signer), form3.WithClock(clock), form3.WithRateLimiter(limiter))
+
+		_, _, _ = client.Accounts.Fetch("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+		_, _, _ = client.Accounts.Fetch("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+		assert.Equal(t, []string{"Wed, 01 Mar 2023 10:00:00 GMT", "Wed, 01 Mar 2023 10:00:02 GMT"}, dates)
+	})
+
+	t.Run("should list and revoke the public keys of a user", func(t *testing.T) {
+		t.Parallel()
+
+		requests := []string{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.Method+" "+r.URL.RequestURI())
+
+			switch r.Method {
+			case http.MethodGet:
+				_, _ = w.Write([]byte(`{"data":{"id":"c1f5a3b2-7d4e-4f6a-9b8c-0d1e2f3a4b5c","version":2}}`))
+			case http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			}
+		}))
+		defer server.Close()
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+
+		_, _, _ = client.Credentials.List(credentialUserID, form3.ListOptions{PageSize: 10})
+		response, error := client.Credentials.Revoke(credentialUserID, "c1f5a3b2-7d4e-4f6a-9b8c-0d1e2f3a4b5c")
+
+		assert.Nil(t, error)
+		assert.Equal(t, 204, response.StatusCode)
+		assert.Equal(t, []string{
+			"GET /v1/security/users/" + credentialUserID + "/credentials/public_key?page%5Bsize%5D=10",
+			"GET /v1/security/users/" + credentialUserID + "/credentials/public_key/c1f5a3b2-7d4e-4f6a-9b8c-0d1e2f3a4b5c",
+			"DELETE /v1/security/users/" + credentialUserID + "/credentials/public_key/c1f5a3b2-7d4e-4f6a-9b8c-0d1e2f3a4b5c?version=2",
+		}, requests)
+	})
+
+	t.Run("should not upload invalid keys or sign with unsupported keys", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := form3.New()
+
+		publicKey, response, error := client.Credentials.Upload(credentialUserID, "ssh-rsa AAAA")
+
+		assert.Nil(t, publicKey)
+		assert.Nil(t, response)
+		assert.Equal(t, form3.ValidationError{Field: "public_key", Message: "must be a PEM encoded public key"}, error)
+
+		_, error = form3.GenerateKey("dsa")
+
+		assert.Equal(t, form3.ValidationError{Field: "algorithm", Message: "must be rsa or ecdsa"}, error)
+
+		privateKey, _ := form3.GenerateKey(form3.KeyAlgorithmEcdsa)
+		signer, error := form3.NewSigner("", privateKey)
+
+		assert.Nil(t, signer)
+		assert.Equal(t, form3.OperationError{Message: "key id is required"}, error)
+	})
+}
@@ -0,0 +1,42 @@
+package form3
+
+// directDebitsUri contains the path to the direct debit resources.
+const directDebitsUri string = "/v1/transaction/directdebits"
+
+// directDebitSubmissionType is the JSON:API type of a direct debit submission.
+const directDebitSubmissionType string = "directdebit_submissions"
+
+// DirectDebitService allows access to operations related to direct debits.
+//
+// A direct debit collects funds from the account of a debtor, using a mandate the debtor agreed to.
+// Direct debits can be created, fetched and listed. A direct debit is only sent once it is submitted.
+//
+// More details available in: https://www.api-docs.form3.tech/api/schemes/bacs/direct-debits/direct-debits
+type DirectDebitService struct {
+	*SubmittableService[DirectDebitData]
+}
+
+// Represents a FORM3 direct debit.
+//
+// More details available in: https://www.api-docs.form3.tech/api/schemes/bacs/direct-debits/direct-debits
+type DirectDebit = Resource[DirectDebitData]
+
+// Represents a FORM3 direct debit data.
+type DirectDebitData struct {
+	Attributes *DirectDebitAttributes `json:"attributes,omitempty"`
+	OrganisationResourceFields
+}
+
+// Represents a FORM3 direct debit attributes.
+type DirectDebitAttributes struct {
+	Amount            string        `json:"amount,omitempty"`
+	BeneficiaryParty  *PaymentParty `json:"beneficiary_party,omitempty"`
+	Currency          string        `json:"currency,omitempty"`
+	DebtorParty       *PaymentParty `json:"debtor_party,omitempty"`
+	MandateID         string        `json:"mandate_id,omitempty"`
+	NumericReference  string        `json:"numeric_reference,omitempty"`
+	PaymentScheme     string        `json:"payment_scheme,omitempty"`
+	ProcessingDate    string        `json:"processing_date,omitempty"`
+	Reference         string        `json:"reference,omitempty"`
+	SchemePaymentType string        `json:"scheme_payment_type,omitempty"`
+}
@@ -1,14 +1,46 @@
 package form3
 
+import "fmt"
+
 // OperationError is used to provide a customized message that is easily consumable by the caller.
 //
 // It used while an operation is being executed and an error occurs.
 type OperationError struct {
-	Message string // Contains customized message, can contain the http status code if the http request was performed.
-	Body    []byte // Contains the http body if the http request was performed.
+	Message   string        // Contains customized message, can contain the http status code if the http request was performed.
+	Body      []byte        // Contains the http body if the http request was performed.
+	Meta      *ResponseMeta // Contains details about the attempts made when no http response was obtained.
+	RequestID string        // Contains the ID of the request returned by the server, if any.
+	Cause     error         // Contains the error that made the operation fail, if any.
 }
 
 // Error returns the message.
 func (e OperationError) Error() string {
 	return e.Message
 }
+
+// Unwrap returns the error that made the operation fail, so it can be checked with errors.Is and errors.As.
+func (e OperationError) Unwrap() error {
+	return e.Cause
+}
+
+// newResponseError creates an error for a http response that does not have the expected status code.
+func newResponseError(response *ResponseMeta, body []byte) OperationError {
+	return OperationError{
+		Message:   response.Status,
+		Body:      body,
+		RequestID: response.RequestID,
+	}
+}
+
+// ValidationError is used when a resource is missing required information or has invalid information.
+//
+// It is returned before any http request is performed.
+type ValidationError struct {
+	Field   string // Contains the json name of the field that is not valid.
+	Message string // Contains the reason why the field is not valid.
+}
+
+// Error returns the field and the reason why it is not valid.
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
@@ -8,82 +8,290 @@ package form3
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"log"
 	"math/rand"
 	"net/http"
 	"net/url"
+	"strconv"
+	"sync"
 	"time"
 )
 
 const (
 	DefaultUrlScheme                = "http"            // DefaultUrlScheme is the default URL scheme.
 	DefaultUrlHost                  = "accountapi:8080" // DefaultUrlHost is the default URL host.
-	DefaultHttpTimeout              = time.Second * 60  // DefaultTimeout is the default timeout on how much time should be used if no http response is obtained.
+	DefaultOperationTimeout         = time.Second * 60  // DefaultOperationTimeout is the default timeout on how much time all attempts of an operation can take.
+	DefaultAttemptTimeout           = time.Second * 20  // DefaultAttemptTimeout is the default timeout on how much time a single attempt can take if no http response is obtained.
 	DefaultHttpRetryAttempts        = 3                 // DefaultHttpRetryAttempts is the default number of attempts when performing a http request.
 	DefaultHttpTimeUntilNextAttempt = 1 * time.Second   // DefaultHttpTimeUntilNextAttempt is the default time until the next http request attemp is made.
 	DefaultDebugEnabled             = false             // DefaultDebug is the default value to determine if debug messages shall be shown.
+	DefaultConflictRetryAttempts    = 3                 // DefaultConflictRetryAttempts is the default number of attempts made again when a resource version conflict happens.
 )
 
 // LogDebugMessage defines the function interface that is used to log debug messages.
 type LogDebugMessage func(format string, v ...any)
 
+// Option defines the function interface that is used to customize a client when it is created.
+type Option func(c *Client) error
+
 // Client is used to access API resourses.
+//
+// Its configuration cannot be changed after it is created, so it is safe to be used by multiple goroutines.
 type Client struct {
-	BaseUrl                   *url.URL        // API base Url to perform http requests.
-	HttpClient                *http.Client    // Http client used to perform http requests.
-	HttpTimeout               time.Duration   // How much time should be used if no http response is obtained.
-	HttpRetryAttempts         int             // How many attempts shall be made if an http cannot be made but can be retried.
-	HttpTimeUntilNextAttempt  time.Duration   // How much time should be spent until the next http retry attempt is done.
-	DebugEnabled              bool            // If debugging messages should be shown.
-	HttpRetryJitterRandomSeed rand.Source     // Random seed used to generate jitter between http retry attempts.
-	Accounts                  *AccountService // Account Service, has access to operations.
-	UserAgent                 string          // Allow the server to identify the client.
-	LogDebugMessage           LogDebugMessage // Allow the client to log debug messages.
+	baseUrl                   *url.URL                    // API base Url to perform http requests.
+	httpClient                *http.Client                // Http client used to perform http requests.
+	operationTimeout          time.Duration               // How much time all attempts of an operation can take, including the time between them.
+	attemptTimeout            time.Duration               // How much time a single attempt can take if no http response is obtained.
+	httpRetryAttempts         int                         // How many attempts shall be made if an http cannot be made but can be retried.
+	httpTimeUntilNextAttempt  time.Duration               // How much time should be spent until the next http retry attempt is done.
+	debugEnabled              bool                        // If debugging messages should be shown.
+	httpRetryJitterRandomSeed rand.Source                 // Random seed used to generate jitter between http retry attempts.
+	userAgent                 string                      // Allow the server to identify the client.
+	logDebugMessage           LogDebugMessage             // Allow the client to log debug messages.
+	conflictRetryAttempts     int                         // How many attempts shall be made again if a resource changed version between being fetched and modified.
+	rateLimiter               *RateLimiter                // Limits how many http requests are performed, there is no limit if not set.
+	signer                    *Signer                     // Signs every http request attempt, requests are not signed if not set.
+	clock                     Clock                       // Used to tell time and to wait between http retry attempts.
+	jitter                    *rand.Rand                  // Generates jitter using the random seed, must only be used while holding the jitter mutex.
+	jitterMutex               sync.Mutex                  // Protects the jitter generator, since random sources are not safe to be used by multiple goroutines.
+	Accounts                  *AccountService             // Account Service, has access to operations.
+	Payments                  *PaymentService             // Payment Service, has access to operations.
+	Mandates                  *MandateService             // Mandate Service, has access to operations.
+	DirectDebits              *DirectDebitService         // Direct Debit Service, has access to operations.
+	ConfirmationOfPayee       *ConfirmationOfPayeeService // Confirmation of Payee Service, has access to operations.
+	Organisations             *OrganisationService        // Organisation Service, has access to operations.
+	Subscriptions             *SubscriptionService        // Subscription Service, has access to operations.
+	BankIDs                   *BankIDService              // Bank ID directory Service, has access to operations.
+	Bics                      *BicService                 // BIC directory Service, has access to operations.
+	Audit                     *AuditService               // Audit Service, has access to operations.
+	Users                     *UserService                // User Service, has access to operations.
+	Roles                     *RoleService                // Role Service, has access to operations.
+	Credentials               *CredentialService          // Credential Service, has access to operations.
 }
 
 // New creates a new client.
 //
 // A set of options can be used to customize it.
 //
-// An error is returned if there is a problem finding a URL scheme and host.
-func New() (*Client, error) {
+// An error is returned if there is a problem applying any of the options.
+func New(options ...Option) (*Client, error) {
 	client := &Client{
-		BaseUrl: &url.URL{
+		baseUrl: &url.URL{
 			Scheme: DefaultUrlScheme,
 			Host:   DefaultUrlHost,
 		},
-		HttpClient:                http.DefaultClient,
-		HttpTimeout:               DefaultHttpTimeout,
-		HttpRetryAttempts:         DefaultHttpRetryAttempts,
-		HttpTimeUntilNextAttempt:  DefaultHttpTimeUntilNextAttempt,
-		DebugEnabled:              DefaultDebugEnabled,
-		HttpRetryJitterRandomSeed: rand.NewSource(time.Now().UnixNano()),
-		UserAgent:                 "form3-client-go",
+		httpClient:               http.DefaultClient,
+		operationTimeout:         DefaultOperationTimeout,
+		attemptTimeout:           DefaultAttemptTimeout,
+		httpRetryAttempts:        DefaultHttpRetryAttempts,
+		httpTimeUntilNextAttempt: DefaultHttpTimeUntilNextAttempt,
+		debugEnabled:             DefaultDebugEnabled,
+		userAgent:                "form3-client-go",
+		logDebugMessage:          log.Printf,
+		conflictRetryAttempts:    DefaultConflictRetryAttempts,
+		clock:                    systemClock{},
 	}
 
-	client.Accounts = &AccountService{Client: client, JsonMarshal: json.Marshal, JsonUnmarshal: json.Unmarshal, ReadAll: io.ReadAll}
-	client.LogDebugMessage = log.Printf
+	for _, option := range options {
+		error := option(client)
+
+		if error != nil {
+			return nil, OperationError{Message: error.Error()}
+		}
+	}
+
+	if client.httpRetryJitterRandomSeed == nil {
+		client.httpRetryJitterRandomSeed = rand.NewSource(client.clock.Now().UnixNano())
+	}
+
+	client.jitter = rand.New(client.httpRetryJitterRandomSeed)
+	client.Accounts = &AccountService{NewResourceService[AccountData](client, accountsUri, "account")}
+	client.Payments = &PaymentService{newSubmittableService(NewResourceService[PaymentData](client, paymentsUri, "payment"), paymentSubmissionType)}
+	client.Mandates = &MandateService{newSubmittableService(NewResourceService[MandateData](client, mandatesUri, "mandate"), mandateSubmissionType)}
+	client.DirectDebits = &DirectDebitService{newSubmittableService(NewResourceService[DirectDebitData](client, directDebitsUri, "direct debit"), directDebitSubmissionType)}
+	client.ConfirmationOfPayee = &ConfirmationOfPayeeService{NewImmutableResourceService[NameVerificationData](client, confirmationOfPayeeUri, "name verification")}
+	client.Organisations = &OrganisationService{NewResourceService[OrganisationData](client, organisationsUri, "organisation")}
+	client.Subscriptions = &SubscriptionService{NewResourceService[SubscriptionData](client, subscriptionsUri, "subscription")}
+	client.BankIDs = &BankIDService{NewReadOnlyResourceService[BankIDData](client, bankIDsUri, "bank id")}
+	client.Bics = &BicService{NewReadOnlyResourceService[BicData](client, bicsUri, "bic")}
+	client.Audit = &AuditService{Client: client}
+	client.Users = &UserService{NewResourceService[UserData](client, usersUri, "user")}
+	client.Roles = &RoleService{NewResourceService[RoleData](client, rolesUri, "role")}
+	client.Credentials = &CredentialService{Client: client}
 
 	return client, nil
 }
 
+// WithBaseUrl sets the API base Url to perform http requests.
+func WithBaseUrl(baseUrl *url.URL) Option {
+	return func(c *Client) error {
+		if baseUrl == nil || baseUrl.Scheme == "" || baseUrl.Host == "" {
+			return fmt.Errorf("base url must have a scheme and a host")
+		}
+
+		copied := *baseUrl
+		c.baseUrl = &copied
+
+		return nil
+	}
+}
+
+// WithHttpClient sets the http client used to perform http requests.
+func WithHttpClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return fmt.Errorf("http client is required")
+		}
+
+		c.httpClient = httpClient
+
+		return nil
+	}
+}
+
+// WithOperationTimeout sets how much time all attempts of an operation can take, including the time between them.
+func WithOperationTimeout(operationTimeout time.Duration) Option {
+	return func(c *Client) error {
+		c.operationTimeout = operationTimeout
+
+		return nil
+	}
+}
+
+// WithAttemptTimeout sets how much time a single attempt can take if no http response is obtained.
+func WithAttemptTimeout(attemptTimeout time.Duration) Option {
+	return func(c *Client) error {
+		c.attemptTimeout = attemptTimeout
+
+		return nil
+	}
+}
+
+// WithHttpRetryAttempts sets how many attempts shall be made if an http cannot be made but can be retried.
+func WithHttpRetryAttempts(httpRetryAttempts int) Option {
+	return func(c *Client) error {
+		c.httpRetryAttempts = httpRetryAttempts
+
+		return nil
+	}
+}
+
+// WithHttpTimeUntilNextAttempt sets how much time should be spent until the next http retry attempt is done.
+func WithHttpTimeUntilNextAttempt(httpTimeUntilNextAttempt time.Duration) Option {
+	return func(c *Client) error {
+		c.httpTimeUntilNextAttempt = httpTimeUntilNextAttempt
+
+		return nil
+	}
+}
+
+// WithDebugEnabled sets if debugging messages should be shown.
+func WithDebugEnabled(debugEnabled bool) Option {
+	return func(c *Client) error {
+		c.debugEnabled = debugEnabled
+
+		return nil
+	}
+}
+
+// WithHttpRetryJitterRandomSeed sets the random seed used to generate jitter between http retry attempts.
+//
+// The client becomes the only user of the seed, it must not be used anywhere else.
+func WithHttpRetryJitterRandomSeed(httpRetryJitterRandomSeed rand.Source) Option {
+	return func(c *Client) error {
+		c.httpRetryJitterRandomSeed = httpRetryJitterRandomSeed
+
+		return nil
+	}
+}
+
+// WithUserAgent sets how the server identifies the client.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		c.userAgent = userAgent
+
+		return nil
+	}
+}
+
+// WithLogDebugMessage sets the function used to log debug messages. It must be safe to be used by multiple goroutines.
+func WithLogDebugMessage(logDebugMessage LogDebugMessage) Option {
+	return func(c *Client) error {
+		c.logDebugMessage = logDebugMessage
+
+		return nil
+	}
+}
+
+// WithConflictRetryAttempts sets how many attempts shall be made again if a resource changed version between being fetched and modified.
+func WithConflictRetryAttempts(conflictRetryAttempts int) Option {
+	return func(c *Client) error {
+		c.conflictRetryAttempts = conflictRetryAttempts
+
+		return nil
+	}
+}
+
+// WithRateLimiter sets the rate limiter used to limit how many http requests are performed.
+func WithRateLimiter(rateLimiter *RateLimiter) Option {
+	return func(c *Client) error {
+		c.rateLimiter = rateLimiter
+
+		return nil
+	}
+}
+
+// WithClock sets the clock used to tell time and to wait between http retry attempts.
+func WithClock(clock Clock) Option {
+	return func(c *Client) error {
+		if clock == nil {
+			return fmt.Errorf("clock is required")
+		}
+
+		c.clock = clock
+
+		return nil
+	}
+}
+
+// BaseUrl returns a copy of the API base Url used to perform http requests.
+func (c *Client) BaseUrl() *url.URL {
+	copied := *c.baseUrl
+
+	return &copied
+}
+
+// HttpClient returns the http client used to perform http requests.
+func (c *Client) HttpClient() *http.Client {
+	return c.httpClient
+}
+
 // PerformRequest uses a client to perform a http request to the API.
 //
 // An error is returned if there was any problem creating or performing the request.
-// Requests can be retried if possible. The time until the next attempt is doubled but it stays within the http timeout.
+// Requests can be retried if possible. The time until the next attempt is doubled.
 // Some jitter is added between requests.
-func (c *Client) PerformRequest(method string, requestURL string, body []byte) (*http.Response, error) {
+//
+// Each attempt is limited by the attempt timeout and all attempts together are limited by the operation timeout.
+// Each attempt is also limited by the time left until the operation deadline, and an attempt whose back-off wait
+// reaches the deadline is not made.
+//
+// The response contains details about all attempts made. If no http response was obtained the details are in the error.
+func (c *Client) PerformRequest(method string, requestURL string, body []byte) (*ResponseMeta, error) {
+	return c.PerformRequestWithContext(context.Background(), method, requestURL, body)
+}
+
+// PerformRequestWithContext is like PerformRequest but the request is cancelled once the provided context is done.
+func (c *Client) PerformRequestWithContext(ctx context.Context, method string, requestURL string, body []byte) (*ResponseMeta, error) {
 	var buffer io.ReadWriter
 
 	if body != nil {
 		buffer = bytes.NewBuffer(body)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), c.HttpTimeout)
-	defer cancel()
-
 	request, _error := http.NewRequest(method, requestURL, buffer)
 
 	if _error != nil {
@@ -94,53 +302,180 @@ func (c *Client) PerformRequest(method string, requestURL string, body []byte) (
 		request.Header.Set("Content-Type", "application/json")
 	}
 
-	request.Header.Set("User-Agent", c.UserAgent)
+	request.Header.Set("User-Agent", c.userAgent)
+
+	// The same request ID is used by all attempts, so that they can be correlated
+	requestID, ok := RequestIDFromContext(ctx)
+
+	if !ok {
+		requestID, _error = newUuid()
+
+		if _error != nil {
+			return nil, OperationError{Message: _error.Error()}
+		}
+	}
+
+	request.Header.Set(RequestIDHeader, requestID)
+
+	// The deadline is kept in clock time, so that retries are planned with the same clock used to wait for them
+	budget := c.operationTimeout
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < budget {
+		budget = time.Until(deadline)
+	}
+
+	// The contexts are only cancelled once the response body is closed, so that the body can still be read
+	ctx, cancel := context.WithTimeout(ctx, c.operationTimeout)
+	meta := newResponseMeta(requestID)
+	start := c.clock.Now()
+	deadline := start.Add(budget)
+
+	response, _error := c.retryRequest(ctx, deadline, c.httpRetryAttempts, c.httpTimeUntilNextAttempt, func() (*http.Response, error) {
+		if c.rateLimiter != nil {
+			_error := c.rateLimiter.Wait(ctx, method)
+
+			if _error != nil {
+				return nil, _error
+			}
+		}
+
+		// The time waiting for the rate limiter does not count for the attempt, which cannot last past the operation deadline
+		attemptTimeout := c.attemptTimeout
+
+		if timeLeft := deadline.Sub(c.clock.Now()); timeLeft < attemptTimeout {
+			attemptTimeout = timeLeft
+		}
+
+		attemptCtx, attemptCancel := context.WithTimeout(ctx, attemptTimeout)
+
+		// Every attempt needs its own request, since the body is consumed when a request is performed
+		attempt := request.Clone(attemptCtx)
+
+		if body != nil {
+			attempt.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		attempt.Header.Set(RequestAttemptHeader, strconv.Itoa(meta.Attempts+1))
+
+		// Every attempt is signed with its own date, after waiting for the rate limiter, so that it is not rejected for being too old
+		if c.signer != nil {
+			_error := c.signer.Sign(attempt, body, c.clock.Now())
+
+			if _error != nil {
+				attemptCancel()
+
+				return nil, _error
+			}
+		}
+
+		attemptStart := c.clock.Now()
+		response, _error := c.httpClient.Do(attempt)
+		meta.Attempts++
+		meta.AttemptDurations = append(meta.AttemptDurations, c.clock.Now().Sub(attemptStart))
 
-	request = request.WithContext(ctx)
+		if c.rateLimiter != nil {
+			c.rateLimiter.adapt(response)
+		}
+
+		if response == nil {
+			attemptCancel()
+
+			return nil, _error
+		}
+
+		response.Body = cancelOnClose{ReadCloser: response.Body, cancel: attemptCancel}
 
-	response, _error := c.retryRequest(c.HttpRetryAttempts, c.HttpTimeUntilNextAttempt, func() (*http.Response, error) {
-		return c.HttpClient.Do(request)
+		return response, _error
 	})
 
+	meta.Latency = c.clock.Now().Sub(start)
+
 	if _error != nil {
-		return nil, OperationError{Message: _error.Error()}
+		cancel()
+
+		return nil, OperationError{Message: _error.Error(), Meta: meta}
 	}
 
-	return response, nil
+	response.Body = cancelOnClose{ReadCloser: response.Body, cancel: cancel}
+	meta.setResponse(response, c.clock.Now())
+
+	return meta, nil
 }
 
-func (c *Client) retryRequest(remainingAttempts int, timeUntilNextAttempt time.Duration, retriable func() (*http.Response, error)) (*http.Response, error) {
+func (c *Client) retryRequest(ctx context.Context, deadline time.Time, remainingAttempts int, timeUntilNextAttempt time.Duration, retriable func() (*http.Response, error)) (*http.Response, error) {
 	response, error := retriable()
 
+	if !c.isRetriable(ctx, response, error) || remainingAttempts <= 0 {
+		return response, error
+	}
+
+	jitter := c.nextJitter(timeUntilNextAttempt)
+	timeUntilNextAttempt = (timeUntilNextAttempt * 2) + jitter
+
+	// Do not wait for an attempt that could only start once the operation deadline is reached
+	if timeLeft := deadline.Sub(c.clock.Now()); timeLeft <= timeUntilNextAttempt {
+		if c.debugEnabled {
+			c.logDebugMessage("DEBUG: Http request failed, not retrying since the next attempt would start after the deadline in: %v", timeLeft)
+		}
+
+		return response, error
+	}
+
+	if c.debugEnabled {
+		c.logDebugMessage("DEBUG: Http request failed, retrying in: %v jitter addded: %v remaining attempts: %d", timeUntilNextAttempt, jitter, remainingAttempts)
+	}
+
+	remainingAttempts--
+
+	if response != nil {
+		response.Body.Close()
+	}
+
+	// Once the context is done the next attempt fails right away
+	select {
+	case <-ctx.Done():
+	case <-c.clock.After(timeUntilNextAttempt):
+	}
+
+	return c.retryRequest(ctx, deadline, remainingAttempts, timeUntilNextAttempt, retriable)
+}
+
+// isRetriable checks if another attempt could be successful.
+func (c *Client) isRetriable(ctx context.Context, response *http.Response, error error) bool {
+	// An attempt that did not finish within the attempt timeout can be retried, as long as the operation is not done.
 	if response == nil {
-		return nil, error
+		return ctx.Err() == nil && errors.Is(error, context.DeadlineExceeded)
 	}
 
 	// Do not retry on client errors. If the client performed too many requests it is still possible to retry.
 	if response.StatusCode >= 400 && response.StatusCode < 500 && response.StatusCode != 429 {
-		return response, error
+		return false
 	}
 
-	if error != nil || response.StatusCode >= 500 || response.StatusCode == 429 {
-		if remainingAttempts > 0 {
-			jitter := time.Duration(rand.Int63n(int64(timeUntilNextAttempt))) / 3
-			timeUntilNextAttempt = (timeUntilNextAttempt * 2) + jitter
+	return error != nil || response.StatusCode >= 500 || response.StatusCode == 429
+}
 
-			// Keep the next attempt within the client timeout
-			if timeUntilNextAttempt > c.HttpTimeout {
-				timeUntilNextAttempt = c.HttpTimeout
-			}
+// cancelOnClose cancels a context once the response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
 
-			if c.DebugEnabled {
-				c.LogDebugMessage("DEBUG: Http request failed, retrying in: %v jitter addded: %v remaining attempts: %d", timeUntilNextAttempt, jitter, remainingAttempts)
-			}
+// Close closes the body and cancels the context.
+func (b cancelOnClose) Close() error {
+	defer b.cancel()
 
-			remainingAttempts--
-			time.Sleep(timeUntilNextAttempt)
+	return b.ReadCloser.Close()
+}
 
-			return c.retryRequest(remainingAttempts, timeUntilNextAttempt, retriable)
-		}
+// nextJitter returns a random jitter up to a third of the time until the next attempt.
+func (c *Client) nextJitter(timeUntilNextAttempt time.Duration) time.Duration {
+	if timeUntilNextAttempt <= 0 {
+		return 0
 	}
 
-	return response, error
+	c.jitterMutex.Lock()
+	defer c.jitterMutex.Unlock()
+
+	return time.Duration(c.jitter.Int63n(int64(timeUntilNextAttempt))) / 3
 }
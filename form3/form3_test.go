@@ -3,15 +3,22 @@
 package form3_test
 
 import (
+	"fmt"
+	"io"
 	"io/ioutil"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
 	"github.com/h2non/gock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -28,37 +35,35 @@ func TestForm3_New(t *testing.T) {
 		assert.Equal(t, &url.URL{
 			Scheme: form3.DefaultUrlScheme,
 			Host:   form3.DefaultUrlHost,
-		}, client.BaseUrl)
-		assert.Equal(t, http.DefaultClient, client.HttpClient)
+		}, client.BaseUrl())
+		assert.Equal(t, http.DefaultClient, client.HttpClient())
 	})
 
 	t.Run("should create new client with url when option is provided", func(t *testing.T) {
 		t.Parallel()
 
 		url, _ := url.ParseRequestURI("http://asdf:8080")
-		client, error := form3.New()
-		client.BaseUrl = url
+		client, error := form3.New(form3.WithBaseUrl(url))
 
 		assert.NotNil(t, client)
 		assert.Nil(t, error)
-		assert.Equal(t, url, client.BaseUrl)
-		assert.Equal(t, http.DefaultClient, client.HttpClient)
+		assert.Equal(t, url, client.BaseUrl())
+		assert.Equal(t, http.DefaultClient, client.HttpClient())
 	})
 
 	t.Run("should create new client with http client when option is provided", func(t *testing.T) {
 		t.Parallel()
 
 		httpClient := &http.Client{}
-		client, error := form3.New()
-		client.HttpClient = &http.Client{}
+		client, error := form3.New(form3.WithHttpClient(&http.Client{}))
 
 		assert.NotNil(t, client)
 		assert.Nil(t, error)
 		assert.Equal(t, &url.URL{
 			Scheme: form3.DefaultUrlScheme,
 			Host:   form3.DefaultUrlHost,
-		}, client.BaseUrl)
-		assert.Equal(t, httpClient, client.HttpClient)
+		}, client.BaseUrl())
+		assert.Equal(t, httpClient, client.HttpClient())
 	})
 
 	t.Run("should create new client with all options provided", func(t *testing.T) {
@@ -66,18 +71,116 @@ func TestForm3_New(t *testing.T) {
 
 		url, _ := url.ParseRequestURI("http://asdf:8080")
 		httpClient := &http.Client{}
-		client, error := form3.New()
-		client.BaseUrl = url
-		client.HttpClient = &http.Client{}
-		client.DebugEnabled = true
-		client.HttpRetryAttempts = 4
-		client.HttpTimeUntilNextAttempt = 3 * time.Second
-		client.HttpTimeout = 10 * time.Second
+		client, error := form3.New(
+			form3.WithBaseUrl(url),
+			form3.WithHttpClient(&http.Client{}),
+			form3.WithDebugEnabled(true),
+			form3.WithHttpRetryAttempts(4),
+			form3.WithHttpTimeUntilNextAttempt(3*time.Second),
+			form3.WithOperationTimeout(10*time.Second),
+			form3.WithAttemptTimeout(2*time.Second),
+		)
 
 		assert.NotNil(t, client)
 		assert.Nil(t, error)
-		assert.Equal(t, url, client.BaseUrl)
-		assert.Equal(t, httpClient, client.HttpClient)
+		assert.Equal(t, url, client.BaseUrl())
+		assert.Equal(t, httpClient, client.HttpClient())
+	})
+}
+
+func TestForm3_NewWithOptions(t *testing.T) {
+	t.Run("should not create new client when an option is not valid", func(t *testing.T) {
+		t.Parallel()
+
+		client, error := form3.New(form3.WithBaseUrl(nil))
+
+		assert.Nil(t, client)
+		assert.Equal(t, form3.OperationError{Message: "base url must have a scheme and a host"}, error)
+	})
+
+	t.Run("should not change the client when the provided url changes", func(t *testing.T) {
+		t.Parallel()
+
+		url, _ := url.ParseRequestURI("http://asdf:8080")
+		client, _ := form3.New(form3.WithBaseUrl(url))
+		url.Host = "changed:8080"
+		client.BaseUrl().Host = "changed:8080"
+
+		assert.Equal(t, "asdf:8080", client.BaseUrl().Host)
+	})
+}
+
+func TestForm3_ConcurrentUse(t *testing.T) {
+	t.Run("should perform operations from multiple goroutines with retries", func(t *testing.T) {
+		t.Parallel()
+
+		attempts := sync.Map{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			attempt, _ := attempts.LoadOrStore(r.Method+r.URL.Path+string(body), new(int32))
+
+			if atomic.AddInt32(attempt.(*int32), 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+
+				return
+			}
+
+			switch r.Method {
+			case http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+				w.Write(body)
+			case http.MethodGet:
+				w.Write([]byte(fmt.Sprintf("{\"data\": {\"id\": \"%s\"}}", path.Base(r.URL.Path))))
+			case http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			}
+		}))
+		defer server.Close()
+
+		baseUrl, _ := url.Parse(server.URL)
+		logMutex := sync.Mutex{}
+		logged := 0
+
+		client, _ := form3.New(
+			form3.WithBaseUrl(baseUrl),
+			form3.WithHttpTimeUntilNextAttempt(50*time.Microsecond),
+			form3.WithHttpRetryAttempts(5),
+			form3.WithHttpRetryJitterRandomSeed(rand.NewSource(0)),
+			form3.WithDebugEnabled(true),
+			form3.WithLogDebugMessage(func(format string, v ...any) {
+				logMutex.Lock()
+				defer logMutex.Unlock()
+
+				logged++
+			}),
+		)
+
+		waitGroup := sync.WaitGroup{}
+
+		for i := 0; i < 20; i++ {
+			accountUuid := fmt.Sprintf("account-%d", i)
+			waitGroup.Add(1)
+
+			go func() {
+				defer waitGroup.Done()
+
+				account := &form3.Account{Data: &form3.AccountData{ID: accountUuid}}
+				createdAccount, _, createError := client.Accounts.Create(account)
+				fetchedAccount, _, fetchError := client.Accounts.Fetch(accountUuid)
+				response, deleteError := client.Accounts.Delete(accountUuid, 0)
+
+				assert.Nil(t, createError)
+				assert.Equal(t, account, createdAccount)
+				assert.Nil(t, fetchError)
+				assert.Equal(t, account, fetchedAccount)
+				assert.Nil(t, deleteError)
+				assert.Equal(t, 204, response.StatusCode)
+			}()
+		}
+
+		waitGroup.Wait()
+
+		assert.Equal(t, 60, logged)
 	})
 }
 
@@ -94,9 +197,10 @@ func TestForm3_PerformRequest(t *testing.T) {
 
 	t.Run("should retry when service unavailable and return the successful response after retries", func(t *testing.T) {
 		defer gock.Off()
-		client, _ := form3.New()
-		client.HttpTimeUntilNextAttempt = 50 * time.Microsecond
-		client.HttpRetryAttempts = 100
+		client, _ := form3.New(
+			form3.WithHttpTimeUntilNextAttempt(50*time.Microsecond),
+			form3.WithHttpRetryAttempts(100),
+		)
 
 		for i := 0; i <= 2; i++ {
 			gock.New("http://test:8080").
@@ -117,11 +221,40 @@ func TestForm3_PerformRequest(t *testing.T) {
 		assert.Equal(t, []byte("{\"outcome\":\"success\"}\n"), body)
 	})
 
+	t.Run("should wait between retries following the exact retry schedule", func(t *testing.T) {
+		defer gock.Off()
+		clock := form3test.NewFakeClock(time.Now()).WithAutoAdvance()
+		client, _ := form3.New(
+			form3.WithAttemptTimeout(5*time.Second),
+			form3.WithHttpTimeUntilNextAttempt(time.Second),
+			form3.WithHttpRetryAttempts(5),
+			form3.WithHttpRetryJitterRandomSeed(rand.NewSource(0)),
+			form3.WithClock(clock),
+		)
+
+		for i := 0; i <= 3; i++ {
+			gock.New("http://test:8080").
+				Get("/endpoint").
+				Reply(503)
+		}
+
+		gock.New("http://test:8080").
+			Get("/endpoint").
+			Reply(200)
+
+		response, error := client.PerformRequest("GET", "http://test:8080/endpoint", nil)
+
+		assert.Nil(t, error)
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, []time.Duration{2247388501, 4921095393, 10222970729, 23723182534}, clock.Waits())
+	})
+
 	t.Run("do not retry when number of retry attempts is configured to be less than 1", func(t *testing.T) {
 		defer gock.Off()
-		client, _ := form3.New()
-		client.HttpTimeUntilNextAttempt = 50 * time.Microsecond
-		client.HttpRetryAttempts = -666
+		client, _ := form3.New(
+			form3.WithHttpTimeUntilNextAttempt(50*time.Microsecond),
+			form3.WithHttpRetryAttempts(-666),
+		)
 
 		gock.New("http://test:8080").
 			Get("/endpoint").
@@ -140,9 +273,10 @@ func TestForm3_PerformRequest(t *testing.T) {
 
 	t.Run("do not retry when the first response contains a client error status code", func(t *testing.T) {
 		defer gock.Off()
-		client, _ := form3.New()
-		client.HttpTimeUntilNextAttempt = 50 * time.Microsecond
-		client.HttpRetryAttempts = 100
+		client, _ := form3.New(
+			form3.WithHttpTimeUntilNextAttempt(50*time.Microsecond),
+			form3.WithHttpRetryAttempts(100),
+		)
 
 		gock.New("http://test:8080").
 			Get("/endpoint").
@@ -161,9 +295,10 @@ func TestForm3_PerformRequest(t *testing.T) {
 
 	t.Run("retry when the response contains too many requests client error status code", func(t *testing.T) {
 		defer gock.Off()
-		client, _ := form3.New()
-		client.HttpTimeUntilNextAttempt = 50 * time.Microsecond
-		client.HttpRetryAttempts = 100
+		client, _ := form3.New(
+			form3.WithHttpTimeUntilNextAttempt(50*time.Microsecond),
+			form3.WithHttpRetryAttempts(100),
+		)
 
 		gock.New("http://test:8080").
 			Get("/endpoint").
@@ -182,23 +317,123 @@ func TestForm3_PerformRequest(t *testing.T) {
 		assert.Equal(t, []byte("{\"outcome\":\"success\"}\n"), body)
 	})
 
-	t.Run("should retry when service unavailable and stay within client http timeout", func(t *testing.T) {
+	t.Run("should not retry when the next attempt would start after the operation deadline", func(t *testing.T) {
 		defer gock.Off()
-		client, _ := form3.New()
-		client.HttpTimeout = 100 * time.Microsecond
-		client.HttpTimeUntilNextAttempt = 50 * time.Microsecond
-		client.HttpRetryAttempts = 100
+		clock := form3test.NewFakeClock(time.Now()).WithAutoAdvance()
+		client, _ := form3.New(
+			form3.WithOperationTimeout(time.Second),
+			form3.WithHttpTimeUntilNextAttempt(2*time.Second),
+			form3.WithHttpRetryAttempts(100),
+			form3.WithClock(clock),
+		)
 
-		for i := 0; i <= 5; i++ {
-			gock.New("http://test:8080").
-				Get("/endpoint").
-				Reply(503)
-		}
+		gock.New("http://test:8080").
+			Get("/endpoint").
+			Reply(503)
 
 		response, error := client.PerformRequest("GET", "http://test:8080/endpoint", []byte{})
 
+		assert.Nil(t, error)
+		assert.Equal(t, 503, response.StatusCode)
+		assert.Empty(t, clock.Waits())
+	})
+
+	t.Run("should not retry when the wait for the next attempt reaches the operation deadline", func(t *testing.T) {
+		defer gock.Off()
+		clock := form3test.NewFakeClock(time.Now()).WithAutoAdvance()
+		client, _ := form3.New(
+			form3.WithOperationTimeout(10*time.Second),
+			form3.WithHttpTimeUntilNextAttempt(time.Second),
+			form3.WithHttpRetryAttempts(100),
+			form3.WithHttpRetryJitterRandomSeed(rand.NewSource(0)),
+			form3.WithClock(clock),
+		)
+
+		gock.New("http://test:8080").
+			Get("/endpoint").
+			Times(5).
+			Reply(503)
+
+		response, error := client.PerformRequest("GET", "http://test:8080/endpoint", nil)
+
+		assert.Nil(t, error)
+		assert.Equal(t, 503, response.StatusCode)
+		assert.Equal(t, 3, response.Attempts)
+		assert.Equal(t, []time.Duration{2247388501, 4921095393}, clock.Waits())
+	})
+
+	t.Run("should not count the time waiting for the rate limiter in the attempt timeout", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("{\"outcome\":\"success\"}"))
+		}))
+		defer server.Close()
+
+		client := newTestClient(t, server.URL,
+			form3.WithAttemptTimeout(100*time.Millisecond),
+			form3.WithHttpRetryAttempts(0),
+			form3.WithRateLimiter(form3.NewRateLimiter(form3.RateLimit{RequestsPerSecond: 5, Burst: 1}, form3.RateLimitWait)),
+		)
+
+		for index := 0; index < 2; index++ {
+			response, error := client.PerformRequest("GET", server.URL, nil)
+
+			assert.Nil(t, error)
+			assert.Equal(t, 200, response.StatusCode)
+		}
+	})
+
+	t.Run("should retry an attempt that takes longer than the attempt timeout within the operation timeout", func(t *testing.T) {
+		t.Parallel()
+
+		attempts := int32(0)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				<-r.Context().Done()
+
+				return
+			}
+
+			w.Write([]byte("{\"outcome\":\"success\"}"))
+		}))
+		defer server.Close()
+
+		client, _ := form3.New(
+			form3.WithOperationTimeout(10*time.Second),
+			form3.WithAttemptTimeout(50*time.Millisecond),
+			form3.WithHttpTimeUntilNextAttempt(time.Second),
+			form3.WithClock(form3test.NewFakeClock(time.Now()).WithAutoAdvance()),
+		)
+
+		response, error := client.PerformRequest("GET", server.URL, nil)
+
+		assert.Nil(t, error)
+		assert.Equal(t, 200, response.StatusCode)
+		body, _ := io.ReadAll(response.Body)
+		assert.Equal(t, []byte("{\"outcome\":\"success\"}"), body)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	})
+
+	t.Run("should not retry an attempt once the operation timeout is reached", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		client, _ := form3.New(
+			form3.WithOperationTimeout(50*time.Millisecond),
+			form3.WithAttemptTimeout(time.Second),
+			form3.WithHttpRetryAttempts(100),
+		)
+
+		response, error := client.PerformRequest("GET", server.URL, nil)
+
 		assert.Nil(t, response)
-		assert.Equal(t, form3.OperationError{Message: "Get \"http://test:8080/endpoint\": context deadline exceeded", Body: nil}, error)
+		assert.Equal(t, fmt.Sprintf("Get \"%s\": context deadline exceeded", server.URL), error.Error())
+		assert.Equal(t, 1, error.(form3.OperationError).Meta.Attempts)
 	})
 
 	t.Run("should retry when service unavailable and print debug messages if debug is enabled", func(t *testing.T) {
@@ -206,14 +441,13 @@ func TestForm3_PerformRequest(t *testing.T) {
 		mockLogDebugMessage := new(LogDebugMessageMock)
 		mockLogDebugMessage.On("LogDebugMessage", mock.Anything, mock.Anything).Return()
 
-		client, _ := form3.New()
-		client.HttpTimeout = 100 * time.Microsecond
-		client.HttpTimeUntilNextAttempt = 50 * time.Microsecond
-		client.HttpRetryAttempts = 1
-		client.DebugEnabled = true
-		rand.Seed(0)
-
-		client.LogDebugMessage = mockLogDebugMessage.LogDebugMessage
+		client, _ := form3.New(
+			form3.WithHttpTimeUntilNextAttempt(50*time.Microsecond),
+			form3.WithHttpRetryAttempts(1),
+			form3.WithDebugEnabled(true),
+			form3.WithHttpRetryJitterRandomSeed(rand.NewSource(0)),
+			form3.WithLogDebugMessage(mockLogDebugMessage.LogDebugMessage),
+		)
 
 		for i := 0; i <= 3; i++ {
 			gock.New("http://test:8080").
@@ -223,7 +457,7 @@ func TestForm3_PerformRequest(t *testing.T) {
 
 		client.PerformRequest("GET", "http://test:8080/endpoint", []byte{})
 
-		mockLogDebugMessage.AssertCalled(t, "LogDebugMessage", "DEBUG: Http request failed, retrying in: %v jitter addded: %v remaining attempts: %d", []interface{}{time.Duration(100000), time.Duration(5168), 1})
+		mockLogDebugMessage.AssertCalled(t, "LogDebugMessage", "DEBUG: Http request failed, retrying in: %v jitter addded: %v remaining attempts: %d", []interface{}{time.Duration(105168), time.Duration(5168), 1})
 	})
 
 	t.Run("should retry when service unavailable and not print debug messages if debug is disabled", func(t *testing.T) {
@@ -231,14 +465,13 @@ func TestForm3_PerformRequest(t *testing.T) {
 		mockLogDebugMessage := new(LogDebugMessageMock)
 		mockLogDebugMessage.On("LogDebugMessage", mock.Anything, mock.Anything).Return()
 
-		client, _ := form3.New()
-		client.HttpTimeout = 100 * time.Microsecond
-		client.HttpTimeUntilNextAttempt = 50 * time.Microsecond
-		client.HttpRetryAttempts = 1
-		client.DebugEnabled = false
-		rand.Seed(0)
-
-		client.LogDebugMessage = mockLogDebugMessage.LogDebugMessage
+		client, _ := form3.New(
+			form3.WithHttpTimeUntilNextAttempt(50*time.Microsecond),
+			form3.WithHttpRetryAttempts(1),
+			form3.WithDebugEnabled(false),
+			form3.WithHttpRetryJitterRandomSeed(rand.NewSource(0)),
+			form3.WithLogDebugMessage(mockLogDebugMessage.LogDebugMessage),
+		)
 
 		for i := 0; i <= 3; i++ {
 			gock.New("http://test:8080").
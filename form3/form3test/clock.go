@@ -0,0 +1,107 @@
+// Package form3test provides utilities to test code that uses the form3 package.
+package form3test
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is a clock whose time only moves when told to.
+//
+// It records every wait so that tests can assert exactly how long the client waited.
+// It is safe to be used by multiple goroutines.
+type FakeClock struct {
+	mutex       sync.Mutex
+	now         time.Time
+	autoAdvance bool
+	waits       []time.Duration
+	waiters     []fakeWaiter
+}
+
+// fakeWaiter is waiting for the clock to reach a given time.
+type fakeWaiter struct {
+	until   time.Time
+	channel chan time.Time
+}
+
+// NewFakeClock creates a new fake clock set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// WithAutoAdvance makes the clock move forward as soon as something waits on it, so waiting takes no time.
+func (c *FakeClock) WithAutoAdvance() *FakeClock {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.autoAdvance = true
+
+	return c
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+// After records the wait and sends the time on the returned channel once the clock is advanced by at least the duration.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	channel := make(chan time.Time, 1)
+	c.waits = append(c.waits, d)
+	c.waiters = append(c.waiters, fakeWaiter{until: c.now.Add(d), channel: channel})
+
+	if c.autoAdvance {
+		c.advance(d)
+	} else {
+		c.advance(0)
+	}
+
+	return channel
+}
+
+// Advance moves the clock forward, releasing everything waiting until then.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.advance(d)
+}
+
+// Waits returns the durations of every wait, in the order they were made.
+func (c *FakeClock) Waits() []time.Duration {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]time.Duration{}, c.waits...)
+}
+
+// Waiters returns how many waits were not released yet.
+func (c *FakeClock) Waiters() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.waiters)
+}
+
+func (c *FakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+	pending := []fakeWaiter{}
+
+	for _, waiter := range c.waiters {
+		if waiter.until.After(c.now) {
+			pending = append(pending, waiter)
+
+			continue
+		}
+
+		waiter.channel <- c.now
+	}
+
+	c.waiters = pending
+}
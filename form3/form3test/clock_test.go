@@ -0,0 +1,44 @@
+//go:build unit
+
+package form3test_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock_After(t *testing.T) {
+	t.Run("should release waits only once the clock is advanced", func(t *testing.T) {
+		t.Parallel()
+
+		start := time.Date(2023, 5, 1, 22, 52, 47, 0, time.UTC)
+		clock := form3test.NewFakeClock(start)
+		channel := clock.After(time.Second)
+
+		clock.Advance(500 * time.Millisecond)
+
+		assert.Len(t, channel, 0)
+		assert.Equal(t, 1, clock.Waiters())
+
+		clock.Advance(500 * time.Millisecond)
+
+		assert.Equal(t, start.Add(time.Second), <-channel)
+		assert.Equal(t, 0, clock.Waiters())
+		assert.Equal(t, []time.Duration{time.Second}, clock.Waits())
+	})
+
+	t.Run("should release waits right away when advancing automatically", func(t *testing.T) {
+		t.Parallel()
+
+		start := time.Date(2023, 5, 1, 22, 52, 47, 0, time.UTC)
+		clock := form3test.NewFakeClock(start).WithAutoAdvance()
+
+		assert.Equal(t, start.Add(time.Minute), <-clock.After(time.Minute))
+		assert.Equal(t, start.Add(time.Minute+time.Second), <-clock.After(time.Second))
+		assert.Equal(t, start.Add(time.Minute+time.Second), clock.Now())
+		assert.Equal(t, []time.Duration{time.Minute, time.Second}, clock.Waits())
+	})
+}
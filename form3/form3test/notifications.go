@@ -0,0 +1,50 @@
+package form3test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+)
+
+// SignNotification sets the digest, timestamp and signature headers of a notification body, signed with a shared secret.
+//
+// Useful to test a form3.NotificationReceiver with payloads signed the same way the server signs them.
+func SignNotification(header http.Header, secret []byte, timestamp time.Time, body []byte) {
+	digest := form3.NotificationDigest(body)
+	seconds := strconv.FormatInt(timestamp.Unix(), 10)
+
+	header.Set(form3.NotificationDigestHeader, digest)
+	header.Set(form3.NotificationTimestampHeader, seconds)
+	header.Set(form3.NotificationSignatureHeader, form3.NotificationSignature(secret, seconds, digest))
+}
+
+// NewNotificationRequest creates a signed request delivering a notification, whose data is encoded as JSON.
+func NewNotificationRequest(url string, secret []byte, timestamp time.Time, notification form3.Notification, data any) (*http.Request, error) {
+	encodedData, error := json.Marshal(data)
+
+	if error != nil {
+		return nil, error
+	}
+
+	notification.Data = encodedData
+	body, error := json.Marshal(notification)
+
+	if error != nil {
+		return nil, error
+	}
+
+	request, error := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+
+	if error != nil {
+		return nil, error
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	SignNotification(request.Header, secret, timestamp, body)
+
+	return request, nil
+}
@@ -0,0 +1,400 @@
+package form3test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// defaultPageSize is the number of resources listed per page when no page size is requested.
+const defaultPageSize = 100
+
+// Server is a fake FORM3 API that keeps resources in memory.
+//
+// Any resource can be created, fetched, listed, updated and deleted following the rules of the FORM3 API:
+// IDs must be unique and versions must match. Paths with an odd number of segments, like "/v1/transaction/payments",
+// are collections and paths with an even number of segments, like "/v1/transaction/payments/{id}", are resources.
+//
+// It is safe to be used by multiple goroutines.
+type Server struct {
+	mutex              sync.Mutex
+	server             *httptest.Server
+	resources          map[string]map[string]any // Data of every resource, by resource path.
+	collections        map[string][]string       // Resource paths in creation order, by collection path.
+	submissionStatuses []string                  // Statuses submissions go through, one every time they are fetched.
+	progress           map[string][]string       // Statuses a submission still has to go through, by resource path.
+}
+
+// NewServer creates and starts a new fake server. It must be closed once it is not needed anymore.
+func NewServer() *Server {
+	s := &Server{
+		resources:   map[string]map[string]any{},
+		collections: map[string][]string{},
+		progress:    map[string][]string{},
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// WithSubmissionStatuses makes created submissions start with the first status and move to the next one every time they are fetched.
+//
+// Useful to test code that waits for a submission to be delivered.
+func (s *Server) WithSubmissionStatuses(statuses ...string) *Server {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.submissionStatuses = append([]string{}, statuses...)
+
+	return s
+}
+
+// URL returns the base url of the server, to be used by a client.
+func (s *Server) URL() *url.URL {
+	serverUrl, _ := url.Parse(s.server.URL)
+
+	return serverUrl
+}
+
+// Close stops the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Resource returns a copy of the data of a resource, if it exists.
+func (s *Server) Resource(path string) (map[string]any, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, ok := s.resources[path]
+
+	if !ok {
+		return nil, false
+	}
+
+	return copyData(data), true
+}
+
+// SetAttribute changes an attribute of a resource, for example to simulate a status change made by the scheme.
+//
+// Returns false if the resource does not exist.
+func (s *Server) SetAttribute(path string, name string, value any) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, ok := s.resources[path]
+
+	if !ok {
+		return false
+	}
+
+	attributes(data)[name] = value
+
+	return true
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	if path == "/v1/health" {
+		reply(w, http.StatusOK, map[string]any{"status": "up"})
+
+		return
+	}
+
+	isCollection := len(strings.Split(strings.TrimPrefix(path, "/"), "/"))%2 == 1
+
+	switch {
+	case r.Method == http.MethodPost && isCollection:
+		s.create(w, r, path)
+	case r.Method == http.MethodGet && isCollection:
+		s.list(w, r, path)
+	case r.Method == http.MethodGet:
+		s.fetch(w, path)
+	case r.Method == http.MethodPatch && !isCollection:
+		s.update(w, r, path)
+	case r.Method == http.MethodDelete && !isCollection:
+		s.delete(w, r, path)
+	default:
+		reply(w, http.StatusMethodNotAllowed, errorMessage("method not allowed"))
+	}
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request, collection string) {
+	data, ok := decodeData(w, r)
+
+	if !ok {
+		return
+	}
+
+	id, _ := data["id"].(string)
+
+	if id == "" {
+		reply(w, http.StatusBadRequest, errorMessage("validation failure list:\nid in body is required"))
+
+		return
+	}
+
+	path := fmt.Sprintf("%s/%s", collection, id)
+
+	if _, exists := s.resources[path]; exists {
+		reply(w, http.StatusConflict, errorMessage("Resource cannot be created as it violates a duplicate constraint"))
+
+		return
+	}
+
+	data["version"] = 0
+
+	if strings.HasSuffix(collection, "/submissions") && len(s.submissionStatuses) > 0 {
+		attributes(data)["status"] = s.submissionStatuses[0]
+		s.progress[path] = s.submissionStatuses[1:]
+	}
+
+	s.resources[path] = data
+	s.collections[collection] = append(s.collections[collection], path)
+
+	reply(w, http.StatusCreated, map[string]any{"data": data, "links": map[string]any{"self": path}})
+}
+
+func (s *Server) fetch(w http.ResponseWriter, path string) {
+	data, ok := s.resources[path]
+
+	if !ok {
+		reply(w, http.StatusNotFound, errorMessage(fmt.Sprintf("record %s does not exist", path[strings.LastIndex(path, "/")+1:])))
+
+		return
+	}
+
+	if statuses := s.progress[path]; len(statuses) > 0 {
+		attributes(data)["status"] = statuses[0]
+		s.progress[path] = statuses[1:]
+	}
+
+	reply(w, http.StatusOK, map[string]any{"data": data, "links": map[string]any{"self": path}})
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, collection string) {
+	query := r.URL.Query()
+	matching := []any{}
+
+	for _, path := range s.collections[collection] {
+		if matches(s.resources[path], query) {
+			matching = append(matching, s.resources[path])
+		}
+	}
+
+	pageNumber, error := strconv.Atoi(query.Get("page[number]"))
+
+	if query.Has("page[number]") && (error != nil || pageNumber < 0) {
+		reply(w, http.StatusBadRequest, errorMessage("validation failure list:\npage[number] in query should be greater than or equal to 0"))
+
+		return
+	}
+
+	pageSize, error := strconv.Atoi(query.Get("page[size]"))
+
+	if error != nil || pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	lastPage := 0
+
+	if len(matching) > 0 {
+		lastPage = (len(matching) - 1) / pageSize
+	}
+
+	start := pageNumber * pageSize
+	end := start + pageSize
+
+	if start > len(matching) {
+		start = len(matching)
+	}
+
+	if end > len(matching) {
+		end = len(matching)
+	}
+
+	page := func(number int) string {
+		pageQuery := url.Values{}
+
+		for key, values := range query {
+			pageQuery[key] = values
+		}
+
+		pageQuery.Set("page[number]", strconv.Itoa(number))
+		pageQuery.Set("page[size]", strconv.Itoa(pageSize))
+
+		return fmt.Sprintf("%s?%s", collection, pageQuery.Encode())
+	}
+
+	links := map[string]any{"self": page(pageNumber), "first": page(0), "last": page(lastPage)}
+
+	if pageNumber < lastPage {
+		links["next"] = page(pageNumber + 1)
+	}
+
+	if pageNumber > 0 {
+		links["prev"] = page(pageNumber - 1)
+	}
+
+	reply(w, http.StatusOK, map[string]any{"data": matching[start:end], "links": links})
+}
+
+func (s *Server) update(w http.ResponseWriter, r *http.Request, path string) {
+	current, ok := s.resources[path]
+
+	if !ok {
+		reply(w, http.StatusNotFound, errorMessage(fmt.Sprintf("record %s does not exist", path[strings.LastIndex(path, "/")+1:])))
+
+		return
+	}
+
+	data, ok := decodeData(w, r)
+
+	if !ok {
+		return
+	}
+
+	if version(data["version"]) != version(current["version"]) {
+		reply(w, http.StatusConflict, errorMessage("invalid version"))
+
+		return
+	}
+
+	for name, value := range attributes(data) {
+		attributes(current)[name] = value
+	}
+
+	current["version"] = version(current["version"]) + 1
+
+	reply(w, http.StatusOK, map[string]any{"data": current, "links": map[string]any{"self": path}})
+}
+
+func (s *Server) delete(w http.ResponseWriter, r *http.Request, path string) {
+	current, ok := s.resources[path]
+
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	requested, error := strconv.ParseInt(r.URL.Query().Get("version"), 10, 64)
+
+	if error != nil || requested != version(current["version"]) {
+		reply(w, http.StatusConflict, errorMessage("invalid version"))
+
+		return
+	}
+
+	collection := path[:strings.LastIndex(path, "/")]
+	remaining := []string{}
+
+	for _, resourcePath := range s.collections[collection] {
+		if resourcePath != path {
+			remaining = append(remaining, resourcePath)
+		}
+	}
+
+	s.collections[collection] = remaining
+	delete(s.resources, path)
+	delete(s.progress, path)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeData reads the data of a JSON:API document, replying with an error if it is not valid.
+func decodeData(w http.ResponseWriter, r *http.Request) (map[string]any, bool) {
+	document := struct {
+		Data map[string]any `json:"data"`
+	}{}
+
+	error := json.NewDecoder(r.Body).Decode(&document)
+
+	if error != nil || document.Data == nil {
+		reply(w, http.StatusBadRequest, errorMessage("validation failure list:\ndata in body is required"))
+
+		return nil, false
+	}
+
+	return document.Data, true
+}
+
+// matches returns if a resource has the values of every filter in the query.
+//
+// Filters apply to attributes, or to fields of the resource itself like "organisation_id" if there is no such attribute.
+func matches(data map[string]any, query url.Values) bool {
+	for key, values := range query {
+		if !strings.HasPrefix(key, "filter[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+
+		name := strings.TrimSuffix(strings.TrimPrefix(key, "filter["), "]")
+		current, _ := data["attributes"].(map[string]any)
+		value, ok := current[name]
+
+		if !ok {
+			value = data[name]
+		}
+
+		if fmt.Sprint(value) != values[0] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// attributes returns the attributes of a resource, creating them if missing.
+func attributes(data map[string]any) map[string]any {
+	current, ok := data["attributes"].(map[string]any)
+
+	if !ok {
+		current = map[string]any{}
+		data["attributes"] = current
+	}
+
+	return current
+}
+
+// version returns a version decoded from json, which can be a float or an integer.
+func version(value any) int64 {
+	switch number := value.(type) {
+	case float64:
+		return int64(number)
+	case int:
+		return int64(number)
+	case int64:
+		return number
+	default:
+		return 0
+	}
+}
+
+// copyData copies the data of a resource, so that it can be changed without changing the stored resource.
+func copyData(data map[string]any) map[string]any {
+	copied := map[string]any{}
+	encoded, _ := json.Marshal(data)
+	_ = json.Unmarshal(encoded, &copied)
+
+	return copied
+}
+
+func errorMessage(message string) map[string]any {
+	return map[string]any{"error_message": message}
+}
+
+func reply(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,197 @@
+//go:build unit
+
+package form3test_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
+	"github.com/stretchr/testify/assert"
+)
+
+func newServerAccount(id string, bankID string) *form3.Account {
+	return &form3.Account{
+		Data: &form3.AccountData{
+			ID:             id,
+			OrganisationID: "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb",
+			Type:           "accounts",
+			Attributes:     &form3.AccountAttributes{Country: "GB", BankID: bankID, Name: []string{"Samantha Holder"}},
+		},
+	}
+}
+
+func TestServer(t *testing.T) {
+	t.Run("should create and fetch a resource", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()), form3.WithHttpRetryAttempts(0))
+
+		created, response, error := client.Accounts.Create(newServerAccount("a1", "400300"))
+
+		assert.Nil(t, error)
+		assert.Equal(t, 201, response.StatusCode)
+		assert.Equal(t, &form3.Links{Self: "/v1/organisation/accounts/a1"}, created.Links)
+
+		fetched, _, error := client.Accounts.Fetch("a1")
+
+		assert.Nil(t, error)
+		assert.Equal(t, created, fetched)
+	})
+
+	t.Run("should not create a resource twice", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()), form3.WithHttpRetryAttempts(0))
+
+		_, _, _ = client.Accounts.Create(newServerAccount("a1", "400300"))
+		_, response, error := client.Accounts.Create(newServerAccount("a1", "400300"))
+
+		assert.Equal(t, 409, response.StatusCode)
+		assert.Equal(t, "409 Conflict", error.Error())
+	})
+
+	t.Run("should not fetch a resource that does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()), form3.WithHttpRetryAttempts(0))
+
+		_, response, error := client.Accounts.Fetch("a1")
+
+		assert.Equal(t, 404, response.StatusCode)
+		assert.Equal(t, []byte("{\"error_message\":\"record a1 does not exist\"}\n"), error.(form3.OperationError).Body)
+	})
+
+	t.Run("should update a resource only when the version matches", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()), form3.WithHttpRetryAttempts(0))
+		account, _, _ := client.Accounts.Create(newServerAccount("a1", "400300"))
+
+		account.Data.Attributes.BankID = "400302"
+		updated, _, error := client.Accounts.Update(account)
+
+		assert.Nil(t, error)
+		assert.Equal(t, int64(1), updated.Data.Version)
+		assert.Equal(t, "400302", updated.Data.Attributes.BankID)
+
+		_, response, _ := client.Accounts.Update(account)
+
+		assert.Equal(t, 409, response.StatusCode)
+	})
+
+	t.Run("should delete a resource only when the version matches", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()), form3.WithHttpRetryAttempts(0))
+		_, _, _ = client.Accounts.Create(newServerAccount("a1", "400300"))
+
+		response, _ := client.Accounts.Delete("a1", 1)
+		assert.Equal(t, 409, response.StatusCode)
+
+		response, error := client.Accounts.Delete("a1", 0)
+		assert.Nil(t, error)
+		assert.Equal(t, 204, response.StatusCode)
+
+		_, exists := server.Resource("/v1/organisation/accounts/a1")
+		assert.False(t, exists)
+	})
+
+	t.Run("should list resources using the pagination and filters", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()), form3.WithHttpRetryAttempts(0))
+
+		for _, id := range []string{"a1", "a2", "a3", "a4"} {
+			_, _, _ = client.Accounts.Create(newServerAccount(id, "400300"))
+		}
+
+		_, _, _ = client.Accounts.Create(newServerAccount("a5", "400302"))
+
+		page, _, error := client.Accounts.List(form3.ListOptions{PageNumber: 1, PageSize: 3, Filter: map[string]string{"bank_id": "400300"}})
+
+		assert.Nil(t, error)
+		assert.Len(t, page.Data, 1)
+		assert.Equal(t, "a4", page.Data[0].ID)
+		assert.Equal(t, "", page.Links.Next)
+		assert.Equal(t, "/v1/organisation/accounts?filter%5Bbank_id%5D=400300&page%5Bnumber%5D=0&page%5Bsize%5D=3", page.Links.Prev)
+	})
+
+	t.Run("should not list resources of a negative page", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+
+		response, error := http.Get(server.URL().String() + "/v1/organisation/accounts?page%5Bnumber%5D=-1")
+
+		assert.Nil(t, error)
+		defer response.Body.Close()
+
+		body, _ := io.ReadAll(response.Body)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		assert.JSONEq(t, `{"error_message":"validation failure list:\npage[number] in query should be greater than or equal to 0"}`, string(body))
+	})
+
+	t.Run("should move submissions through the statuses every time they are fetched", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer().WithSubmissionStatuses(form3.PaymentSubmissionQueuedForDelivery, form3.PaymentSubmissionDeliveryConfirmed)
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()), form3.WithHttpRetryAttempts(0))
+		payment := &form3.Payment{Data: &form3.PaymentData{OrganisationResourceFields: form3.OrganisationResourceFields{ID: "p1", OrganisationID: "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb"}}}
+
+		submission, _, _ := client.Payments.Submit(payment)
+		assert.Equal(t, form3.PaymentSubmissionQueuedForDelivery, submission.Data.Attributes.Status)
+
+		submission, _, _ = client.Payments.Submissions("p1").Fetch(submission.Data.ID)
+		assert.Equal(t, form3.PaymentSubmissionDeliveryConfirmed, submission.Data.Attributes.Status)
+
+		submission, _, _ = client.Payments.Submissions("p1").Fetch(submission.Data.ID)
+		assert.Equal(t, form3.PaymentSubmissionDeliveryConfirmed, submission.Data.Attributes.Status)
+	})
+
+	t.Run("should change the attributes of a resource", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()), form3.WithHttpRetryAttempts(0))
+		_, _, _ = client.Accounts.Create(newServerAccount("a1", "400300"))
+
+		assert.True(t, server.SetAttribute("/v1/organisation/accounts/a1", "status", "closed"))
+		assert.False(t, server.SetAttribute("/v1/organisation/accounts/a2", "status", "closed"))
+
+		account, _, _ := client.Accounts.Fetch("a1")
+		assert.Equal(t, "closed", account.Data.Attributes.Status)
+	})
+
+	t.Run("should be healthy", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()), form3.WithHttpRetryAttempts(0))
+
+		health, _, error := client.Health(context.Background())
+
+		assert.Nil(t, error)
+		assert.True(t, health.IsHealthy())
+	})
+}
@@ -0,0 +1,108 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// healthUri contains the path to the health check.
+const healthUri string = "/v1/health"
+
+// DefaultHealthInterval is the default time between the checks made while waiting for the FORM3 API to be healthy.
+const DefaultHealthInterval = time.Second
+
+const (
+	HealthStatusUp   = "up"   // HealthStatusUp is the status returned by the server when it is able to handle requests.
+	HealthStatusDown = "down" // HealthStatusDown is the status used when the server is not able to handle requests.
+)
+
+// Health represents the status of the FORM3 API.
+type Health struct {
+	Status string `json:"status"`
+}
+
+// IsHealthy returns if the server is able to handle requests.
+func (h *Health) IsHealthy() bool {
+	return h != nil && h.Status == HealthStatusUp
+}
+
+// Health allows one to check the status of the FORM3 API.
+//
+// An OperationError is returned if the server did not reply with a successful status code, together with its response.
+func (c *Client) Health(ctx context.Context) (*Health, *ResponseMeta, error) {
+	requestURL := fmt.Sprintf("%s%s", c.baseUrl, healthUri)
+
+	response, error := c.PerformRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+
+	if error != nil {
+		return nil, nil, error
+	}
+
+	defer response.Body.Close()
+
+	body, error := io.ReadAll(response.Body)
+
+	if error != nil {
+		return nil, response, OperationError{Message: error.Error()}
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, response, newResponseError(response, body)
+	}
+
+	health := Health{}
+	error = json.Unmarshal(body, &health)
+
+	if error != nil {
+		return nil, response, OperationError{Message: error.Error(), Body: body}
+	}
+
+	return &health, response, nil
+}
+
+// WaitUntilHealthy checks the status of the FORM3 API every interval until it is healthy or the context is done.
+//
+// Useful to wait for the server to start. The time between checks is measured with the client clock,
+// DefaultHealthInterval is used if the interval is not positive.
+func (c *Client) WaitUntilHealthy(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultHealthInterval
+	}
+
+	for {
+		health, _, _ := c.Health(ctx)
+
+		if health.IsHealthy() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return OperationError{Message: ctx.Err().Error()}
+		case <-c.clock.After(interval):
+		}
+	}
+}
+
+// HealthHandler returns a http handler that replies with the status of the FORM3 API.
+//
+// It replies with 200 if the server is healthy and 503 otherwise, so it can be used as a readiness probe.
+func (c *Client) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		health, _, error := c.Health(r.Context())
+		statusCode := http.StatusOK
+
+		if error != nil || !health.IsHealthy() {
+			health = &Health{Status: HealthStatusDown}
+			statusCode = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_ = json.NewEncoder(w).Encode(health)
+	})
+}
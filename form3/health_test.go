@@ -0,0 +1,157 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
+	"github.com/stretchr/testify/assert"
+)
+
+// newHealthServer creates a server that replies with the given statuses in order, repeating the last one.
+func newHealthServer(t *testing.T, statusCodes []int, bodies []string) *httptest.Server {
+	mutex := sync.Mutex{}
+	calls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/health", r.URL.Path)
+
+		mutex.Lock()
+		index := calls
+		calls++
+		mutex.Unlock()
+
+		if index >= len(statusCodes) {
+			index = len(statusCodes) - 1
+		}
+
+		w.WriteHeader(statusCodes[index])
+		_, _ = w.Write([]byte(bodies[index]))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestHealth(t *testing.T) {
+	t.Run("should return the status when the server is up", func(t *testing.T) {
+		t.Parallel()
+
+		server := newHealthServer(t, []int{200}, []string{`{"status":"up"}`})
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+
+		health, response, error := client.Health(context.Background())
+
+		assert.Nil(t, error)
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, &form3.Health{Status: form3.HealthStatusUp}, health)
+		assert.True(t, health.IsHealthy())
+	})
+
+	t.Run("should return an error when the server does not reply successfully", func(t *testing.T) {
+		t.Parallel()
+
+		server := newHealthServer(t, []int{503}, []string{`{"status":"down"}`})
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+
+		health, response, error := client.Health(context.Background())
+
+		assert.Nil(t, health)
+		assert.Equal(t, 503, response.StatusCode)
+		assert.Equal(t, form3.OperationError{Message: "503 Service Unavailable", Body: []byte(`{"status":"down"}`)}, error)
+		assert.False(t, health.IsHealthy())
+	})
+
+	t.Run("should return an error when the status cannot be parsed", func(t *testing.T) {
+		t.Parallel()
+
+		server := newHealthServer(t, []int{200}, []string{`up`})
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+
+		health, _, error := client.Health(context.Background())
+
+		assert.Nil(t, health)
+		assert.Equal(t, []byte(`up`), error.(form3.OperationError).Body)
+	})
+}
+
+func TestHealth_WaitUntilHealthy(t *testing.T) {
+	t.Run("should check the status every interval until the server is healthy", func(t *testing.T) {
+		t.Parallel()
+
+		server := newHealthServer(t, []int{503, 200, 200}, []string{``, `{"status":"down"}`, `{"status":"up"}`})
+		clock := form3test.NewFakeClock(time.Now()).WithAutoAdvance()
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0), form3.WithClock(clock))
+
+		error := client.WaitUntilHealthy(context.Background(), 5*time.Second)
+
+		assert.Nil(t, error)
+		assert.Equal(t, []time.Duration{5 * time.Second, 5 * time.Second}, clock.Waits())
+	})
+
+	t.Run("should wait the default interval between checks when the interval is not positive", func(t *testing.T) {
+		t.Parallel()
+
+		server := newHealthServer(t, []int{503, 200}, []string{``, `{"status":"up"}`})
+		clock := form3test.NewFakeClock(time.Now()).WithAutoAdvance()
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0), form3.WithClock(clock))
+
+		error := client.WaitUntilHealthy(context.Background(), 0)
+
+		assert.Nil(t, error)
+		assert.Equal(t, []time.Duration{form3.DefaultHealthInterval}, clock.Waits())
+	})
+
+	t.Run("should return an error when the context is done before the server is healthy", func(t *testing.T) {
+		t.Parallel()
+
+		server := newHealthServer(t, []int{503}, []string{``})
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		error := client.WaitUntilHealthy(ctx, 10*time.Millisecond)
+
+		assert.Equal(t, form3.OperationError{Message: "context deadline exceeded"}, error)
+	})
+}
+
+func TestHealth_HealthHandler(t *testing.T) {
+	tests := []struct {
+		description  string
+		statusCode   int
+		body         string
+		expected     int
+		expectedBody string
+	}{
+		{description: "should reply successfully when the server is healthy", statusCode: 200, body: `{"status":"up"}`, expected: 200, expectedBody: "{\"status\":\"up\"}\n"},
+		{description: "should reply unavailable when the server is not healthy", statusCode: 200, body: `{"status":"starting"}`, expected: 503, expectedBody: "{\"status\":\"down\"}\n"},
+		{description: "should reply unavailable when the server cannot be reached", statusCode: 502, body: ``, expected: 503, expectedBody: "{\"status\":\"down\"}\n"},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			server := newHealthServer(t, []int{test.statusCode}, []string{test.body})
+			client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+			recorder := httptest.NewRecorder()
+
+			client.HealthHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+			assert.Equal(t, test.expected, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,24 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient creates a client of the server at the given URL, customized by the given options.
+func newTestClient(t *testing.T, serverUrl string, options ...form3.Option) *form3.Client {
+	baseUrl, error := url.Parse(serverUrl)
+
+	assert.Nil(t, error)
+
+	client, error := form3.New(append([]form3.Option{form3.WithBaseUrl(baseUrl)}, options...)...)
+
+	assert.Nil(t, error)
+
+	return client
+}
@@ -0,0 +1,127 @@
+package form3
+
+import (
+	"context"
+	"fmt"
+)
+
+// mandatesUri contains the path to the mandate resources.
+const mandatesUri string = "/v1/transaction/mandates"
+
+const (
+	mandateSubmissionType   string = "mandate_submissions"   // mandateSubmissionType is the JSON:API type of a mandate submission.
+	mandateCancellationType string = "mandate_cancellations" // mandateCancellationType is the JSON:API type of a mandate cancellation.
+)
+
+const (
+	MandateSchemeBacs = "BACS"            // MandateSchemeBacs is used by mandates of Bacs direct debits.
+	MandateSchemeSepa = "SEPADIRECTDEBIT" // MandateSchemeSepa is used by mandates of SEPA direct debits.
+)
+
+// MandateService allows access to operations related to direct debit mandates.
+//
+// A mandate allows a beneficiary to collect funds from the account of a debtor using direct debits.
+// Mandates can be created, fetched, listed and cancelled. A mandate is only sent once it is submitted.
+// Mandates received from the scheme are available as admissions.
+//
+// More details available in: https://www.api-docs.form3.tech/api/schemes/bacs/mandates/mandates
+type MandateService struct {
+	*SubmittableService[MandateData]
+}
+
+// Represents a FORM3 direct debit mandate.
+//
+// More details available in: https://www.api-docs.form3.tech/api/schemes/bacs/mandates/mandates
+type Mandate = Resource[MandateData]
+
+// Represents a FORM3 direct debit mandate data.
+type MandateData struct {
+	Attributes *MandateAttributes `json:"attributes,omitempty"`
+	OrganisationResourceFields
+}
+
+// Represents a FORM3 direct debit mandate attributes.
+type MandateAttributes struct {
+	BeneficiaryParty     *PaymentParty `json:"beneficiary_party,omitempty"`
+	DebtorParty          *PaymentParty `json:"debtor_party,omitempty"`
+	PaymentScheme        string        `json:"payment_scheme,omitempty"`
+	ProcessingDate       string        `json:"processing_date,omitempty"`
+	Reference            string        `json:"reference,omitempty"`
+	SchemeProcessingDate string        `json:"scheme_processing_date,omitempty"`
+	SignatureDate        string        `json:"signature_date,omitempty"`
+	Status               string        `json:"status,omitempty"`
+}
+
+// Represents a FORM3 mandate admission, which is a mandate received from the scheme.
+//
+// More details available in: https://www.api-docs.form3.tech/api/schemes/bacs/mandates/mandate-admissions
+type MandateAdmission = Resource[MandateAdmissionData]
+
+// Represents a FORM3 mandate admission data.
+type MandateAdmissionData struct {
+	Attributes *MandateAdmissionAttributes `json:"attributes,omitempty"`
+	OrganisationResourceFields
+}
+
+// Represents a FORM3 mandate admission attributes.
+type MandateAdmissionAttributes struct {
+	AdmissionDatetime string `json:"admission_datetime,omitempty"`
+	SchemeStatusCode  string `json:"scheme_status_code,omitempty"`
+	Status            string `json:"status,omitempty"`
+	StatusReason      string `json:"status_reason,omitempty"`
+}
+
+// Represents a FORM3 mandate cancellation, which stops a mandate from being used to collect funds.
+type MandateCancellation = Resource[MandateCancellationData]
+
+// Represents a FORM3 mandate cancellation data.
+type MandateCancellationData struct {
+	Attributes *MandateCancellationAttributes `json:"attributes,omitempty"`
+	OrganisationResourceFields
+}
+
+// Represents a FORM3 mandate cancellation attributes.
+type MandateCancellationAttributes struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// Admissions allows access to the admissions of a mandate, which can only be created, fetched and listed.
+func (s *MandateService) Admissions(mandateId string) *ImmutableResourceService[MandateAdmissionData] {
+	admissions := newSubResourceService[MandateAdmissionData](s.resources, fmt.Sprintf("%s/admissions", mandateId), "mandate admission")
+
+	return &ImmutableResourceService[MandateAdmissionData]{resources: admissions}
+}
+
+// Cancellations allows access to the cancellations of a mandate, which can only be created, fetched and listed.
+func (s *MandateService) Cancellations(mandateId string) *ImmutableResourceService[MandateCancellationData] {
+	cancellations := newSubResourceService[MandateCancellationData](s.resources, fmt.Sprintf("%s/cancellations", mandateId), "mandate cancellation")
+
+	return &ImmutableResourceService[MandateCancellationData]{resources: cancellations}
+}
+
+// Cancel allows one to cancel a mandate, so that it cannot be used to collect funds anymore.
+func (s *MandateService) Cancel(mandate *Mandate, reason string) (*MandateCancellation, *ResponseMeta, error) {
+	return s.CancelWithContext(context.Background(), mandate, reason)
+}
+
+// CancelWithContext is like Cancel but the request is cancelled once the provided context is done.
+func (s *MandateService) CancelWithContext(ctx context.Context, mandate *Mandate, reason string) (*MandateCancellation, *ResponseMeta, error) {
+	if mandate == nil || mandate.Data == nil {
+		return nil, nil, OperationError{Message: "mandate data is required"}
+	}
+
+	id, error := newUuid()
+
+	if error != nil {
+		return nil, nil, OperationError{Message: error.Error()}
+	}
+
+	cancellation := &MandateCancellation{
+		Data: &MandateCancellationData{
+			OrganisationResourceFields: OrganisationResourceFields{ID: id, OrganisationID: mandate.Data.OrganisationID, Type: mandateCancellationType},
+			Attributes:                 &MandateCancellationAttributes{Reason: reason},
+		},
+	}
+
+	return s.Cancellations(mandate.Data.ID).CreateWithContext(ctx, cancellation)
+}
@@ -0,0 +1,171 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
+	"github.com/stretchr/testify/assert"
+)
+
+const mandatesOrganisationID = "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb"
+
+func newMandatesClient(t *testing.T, server *form3test.Server) (*form3.Client, *form3.Mandate) {
+	client := newTestClient(t, server.URL().String(), form3.WithClock(form3test.NewFakeClock(time.Now()).WithAutoAdvance()))
+
+	mandate, _, error := client.Mandates.Create(&form3.Mandate{
+		Data: &form3.MandateData{
+			OrganisationResourceFields: form3.OrganisationResourceFields{ID: "0d209d7f-d07a-4542-947f-5885fddddae2", OrganisationID: mandatesOrganisationID, Type: "mandates"},
+			Attributes: &form3.MandateAttributes{
+				PaymentScheme: form3.MandateSchemeBacs,
+				Reference:     "GYM-MEMBERSHIP-001",
+				DebtorParty:   &form3.PaymentParty{AccountNumber: "41426819", AccountWith: &form3.PaymentAccountHolder{BankID: "400300", BankIDCode: "GBDSC"}},
+			},
+		},
+	})
+
+	assert.Nil(t, error)
+
+	return client, mandate
+}
+
+func TestMandates(t *testing.T) {
+	t.Run("should create and submit a mandate", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer().WithSubmissionStatuses(form3.PaymentSubmissionQueuedForDelivery, form3.PaymentSubmissionDeliveryConfirmed)
+		defer server.Close()
+		client, mandate := newMandatesClient(t, server)
+
+		submission, _, error := client.Mandates.Submit(mandate)
+		assert.Nil(t, error)
+
+		stored, _ := server.Resource("/v1/transaction/mandates/0d209d7f-d07a-4542-947f-5885fddddae2/submissions/" + submission.Data.ID)
+		assert.Equal(t, "mandate_submissions", stored["type"])
+
+		submission, _, error = client.Mandates.WaitForSubmission(context.Background(), mandate.Data.ID, submission.Data.ID, time.Second)
+
+		assert.Nil(t, error)
+		assert.Equal(t, form3.PaymentSubmissionDeliveryConfirmed, submission.Data.Attributes.Status)
+	})
+
+	t.Run("should list the mandates", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, mandate := newMandatesClient(t, server)
+
+		mandates, _, error := client.Mandates.List(form3.ListOptions{})
+
+		assert.Nil(t, error)
+		assert.Equal(t, []form3.MandateData{*mandate.Data}, mandates.Data)
+	})
+
+	t.Run("should cancel a mandate", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, mandate := newMandatesClient(t, server)
+
+		cancellation, response, error := client.Mandates.Cancel(mandate, "Membership ended")
+
+		assert.Nil(t, error)
+		assert.Equal(t, 201, response.StatusCode)
+		assert.Equal(t, "Membership ended", cancellation.Data.Attributes.Reason)
+
+		stored, _ := server.Resource("/v1/transaction/mandates/0d209d7f-d07a-4542-947f-5885fddddae2/cancellations/" + cancellation.Data.ID)
+		assert.Equal(t, "mandate_cancellations", stored["type"])
+		assert.Equal(t, mandatesOrganisationID, stored["organisation_id"])
+	})
+
+	t.Run("should not cancel a mandate without data", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := form3.New()
+
+		cancellation, response, error := client.Mandates.Cancel(&form3.Mandate{}, "Membership ended")
+
+		assert.Nil(t, cancellation)
+		assert.Nil(t, response)
+		assert.Equal(t, form3.OperationError{Message: "mandate data is required"}, error)
+	})
+
+	t.Run("should fetch the admissions of a mandate", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, mandate := newMandatesClient(t, server)
+		admissions := client.Mandates.Admissions(mandate.Data.ID)
+		_, _, _ = admissions.Create(&form3.MandateAdmission{Data: &form3.MandateAdmissionData{OrganisationResourceFields: form3.OrganisationResourceFields{ID: "a1"}, Attributes: &form3.MandateAdmissionAttributes{Status: "confirmed"}}})
+
+		admission, _, error := admissions.Fetch("a1")
+
+		assert.Nil(t, error)
+		assert.Equal(t, "confirmed", admission.Data.Attributes.Status)
+	})
+
+	t.Run("should only allow mandates, direct debits and their sub-resources to be created, fetched and listed", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := form3.New()
+
+		for _, service := range []any{
+			client.Mandates,
+			client.Mandates.Admissions("0c7f5b3e-5d1a-4b2c-9e8f-7a6b5c4d3e2f"),
+			client.Mandates.Cancellations("0c7f5b3e-5d1a-4b2c-9e8f-7a6b5c4d3e2f"),
+			client.DirectDebits,
+		} {
+			serviceType := reflect.TypeOf(service)
+
+			for _, method := range []string{"Create", "Fetch", "List"} {
+				_, ok := serviceType.MethodByName(method)
+				assert.True(t, ok, serviceType.String()+"."+method)
+			}
+
+			for _, method := range []string{"Update", "UpdateWithRetry", "Delete", "DeleteLatest"} {
+				_, ok := serviceType.MethodByName(method)
+				assert.False(t, ok, serviceType.String()+"."+method)
+			}
+		}
+	})
+}
+
+func TestDirectDebits(t *testing.T) {
+	t.Run("should collect funds using a mandate", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer().WithSubmissionStatuses(form3.PaymentSubmissionDeliveryConfirmed)
+		defer server.Close()
+		client, mandate := newMandatesClient(t, server)
+
+		directDebit, _, error := client.DirectDebits.Create(&form3.DirectDebit{
+			Data: &form3.DirectDebitData{
+				OrganisationResourceFields: form3.OrganisationResourceFields{ID: "7eb8277a-6c91-45e9-8a03-a27f82aca350", OrganisationID: mandatesOrganisationID, Type: "directdebits"},
+				Attributes: &form3.DirectDebitAttributes{
+					Amount:        "35.00",
+					Currency:      "GBP",
+					MandateID:     mandate.Data.ID,
+					PaymentScheme: form3.MandateSchemeBacs,
+					DebtorParty:   mandate.Data.Attributes.DebtorParty,
+				},
+			},
+		})
+		assert.Nil(t, error)
+
+		submission, _, error := client.DirectDebits.Submit(directDebit)
+
+		assert.Nil(t, error)
+		assert.Equal(t, form3.PaymentSubmissionDeliveryConfirmed, submission.Data.Attributes.Status)
+
+		stored, _ := server.Resource("/v1/transaction/directdebits/7eb8277a-6c91-45e9-8a03-a27f82aca350/submissions/" + submission.Data.ID)
+		assert.Equal(t, "directdebit_submissions", stored["type"])
+	})
+}
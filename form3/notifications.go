@@ -0,0 +1,324 @@
+package form3
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	NotificationDigestHeader    = "Digest"            // NotificationDigestHeader contains the SHA-256 digest of the notification body.
+	NotificationTimestampHeader = "X-Form3-Timestamp" // NotificationTimestampHeader contains when the notification was sent, in seconds since the Unix epoch.
+	NotificationSignatureHeader = "X-Form3-Signature" // NotificationSignatureHeader contains the HMAC-SHA256 of the timestamp and the digest, hex encoded.
+)
+
+// defaultNotificationTolerance is how far the timestamp of a notification can be from the current time by default.
+const defaultNotificationTolerance = 5 * time.Minute
+
+// maxNotificationSize is the maximum size of a notification body, in bytes.
+const maxNotificationSize = 1 << 20
+
+// Represents a FORM3 notification, sent by the server to the callback URI of a subscription.
+type Notification struct {
+	ID             string          `json:"id"`
+	OrganisationID string          `json:"organisation_id"`
+	EventType      EventType       `json:"event_type"`
+	RecordType     RecordType      `json:"record_type"`
+	Version        int64           `json:"version"`
+	Data           json.RawMessage `json:"data"`
+}
+
+// AccountEvent is a notification about an account.
+type AccountEvent struct {
+	*Notification
+	Account *AccountData // Account as it was when the notification was sent.
+}
+
+// PaymentEvent is a notification about a payment.
+type PaymentEvent struct {
+	*Notification
+	Payment *PaymentData // Payment as it was when the notification was sent.
+}
+
+// PaymentSubmissionEvent is a notification about the status of a payment submission.
+type PaymentSubmissionEvent struct {
+	*Notification
+	Submission *PaymentSubmissionData // Submission as it was when the notification was sent.
+	Status     string                 // Status of the submission, for example PaymentSubmissionDeliveryConfirmed.
+}
+
+// NotificationHandler handles a notification. If an error is returned the notification is not acknowledged,
+// so that it is sent again by the server. A ValidationError rejects the notification as not valid instead.
+type NotificationHandler func(ctx context.Context, notification *Notification) error
+
+// NotificationReceiver is a http.Handler that receives the notifications of subscriptions using the http transport.
+//
+// Every notification must be signed with the shared secret and sent within the tolerance of the current time,
+// otherwise it is rejected. A notification ID is only accepted once, so that replayed notifications are rejected.
+// Accepted notifications are dispatched to the handlers registered for their record and event types.
+//
+// It is safe to be used by multiple goroutines.
+type NotificationReceiver struct {
+	mutex     sync.Mutex
+	secret    []byte
+	clock     Clock
+	tolerance time.Duration
+	handlers  map[string][]NotificationHandler // Handlers by record and event type.
+	received  map[string]time.Time             // Timestamps of the accepted notifications, by notification ID.
+}
+
+// NewNotificationReceiver creates a receiver of notifications signed with a shared secret.
+func NewNotificationReceiver(secret []byte) *NotificationReceiver {
+	return &NotificationReceiver{
+		secret:    append([]byte{}, secret...),
+		clock:     systemClock{},
+		tolerance: defaultNotificationTolerance,
+		handlers:  map[string][]NotificationHandler{},
+		received:  map[string]time.Time{},
+	}
+}
+
+// WithClock sets the clock used to check the timestamp of notifications.
+func (r *NotificationReceiver) WithClock(clock Clock) *NotificationReceiver {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.clock = clock
+
+	return r
+}
+
+// WithTolerance sets how far the timestamp of a notification can be from the current time, by default 5 minutes.
+func (r *NotificationReceiver) WithTolerance(tolerance time.Duration) *NotificationReceiver {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.tolerance = tolerance
+
+	return r
+}
+
+// Handle registers a handler for the notifications of a record and event type.
+//
+// Handlers are called in the order they were registered, until one of them returns an error.
+func (r *NotificationReceiver) Handle(recordType RecordType, eventType EventType, handler NotificationHandler) *NotificationReceiver {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := notificationKey(recordType, eventType)
+	r.handlers[key] = append(r.handlers[key], handler)
+
+	return r
+}
+
+// OnAccountCreated registers a handler for accounts being created.
+func (r *NotificationReceiver) OnAccountCreated(handler func(ctx context.Context, event *AccountEvent) error) *NotificationReceiver {
+	return r.Handle(RecordTypeAccounts, EventTypeCreated, typedNotificationHandler(newAccountEvent, handler))
+}
+
+// OnAccountUpdated registers a handler for accounts being updated.
+func (r *NotificationReceiver) OnAccountUpdated(handler func(ctx context.Context, event *AccountEvent) error) *NotificationReceiver {
+	return r.Handle(RecordTypeAccounts, EventTypeUpdated, typedNotificationHandler(newAccountEvent, handler))
+}
+
+// OnPaymentCreated registers a handler for payments being created.
+func (r *NotificationReceiver) OnPaymentCreated(handler func(ctx context.Context, event *PaymentEvent) error) *NotificationReceiver {
+	return r.Handle(RecordTypePayments, EventTypeCreated, typedNotificationHandler(newPaymentEvent, handler))
+}
+
+// OnPaymentSubmissionStatus registers a handler for payment submissions being created or changing status.
+func (r *NotificationReceiver) OnPaymentSubmissionStatus(handler func(ctx context.Context, event *PaymentSubmissionEvent) error) *NotificationReceiver {
+	typed := typedNotificationHandler(newPaymentSubmissionEvent, handler)
+
+	return r.Handle(RecordTypePaymentSubmissions, EventTypeCreated, typed).Handle(RecordTypePaymentSubmissions, EventTypeUpdated, typed)
+}
+
+// ServeHTTP verifies a notification and dispatches it to its handlers.
+//
+// Replies 204 once the notification is handled, 400 if it cannot be decoded or a handler returned a ValidationError,
+// 401 if it is not correctly signed or outside the tolerance, 409 if it was already received and 500 if a handler
+// returned any other error.
+func (r *NotificationReceiver) ServeHTTP(w http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	body, error := io.ReadAll(http.MaxBytesReader(w, request.Body, maxNotificationSize))
+
+	if error != nil {
+		http.Error(w, "notification body could not be read", http.StatusBadRequest)
+
+		return
+	}
+
+	timestamp, error := r.verify(request.Header, body)
+
+	if error != nil {
+		http.Error(w, error.Error(), http.StatusUnauthorized)
+
+		return
+	}
+
+	notification := &Notification{}
+	error = json.Unmarshal(body, notification)
+
+	if error != nil || notification.ID == "" {
+		http.Error(w, "notification could not be decoded", http.StatusBadRequest)
+
+		return
+	}
+
+	handlers, accepted := r.accept(notification, timestamp)
+
+	if !accepted {
+		http.Error(w, fmt.Sprintf("notification %s was already received", notification.ID), http.StatusConflict)
+
+		return
+	}
+
+	for _, handler := range handlers {
+		error = handler(request.Context(), notification)
+
+		if error != nil {
+			r.forget(notification.ID)
+
+			if _, invalid := error.(ValidationError); invalid {
+				http.Error(w, fmt.Sprintf("notification %s %s", notification.ID, error.Error()), http.StatusBadRequest)
+
+				return
+			}
+
+			http.Error(w, fmt.Sprintf("notification %s could not be handled", notification.ID), http.StatusInternalServerError)
+
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// verify checks the digest, the timestamp and the signature of a notification, returning when it was sent.
+func (r *NotificationReceiver) verify(header http.Header, body []byte) (time.Time, error) {
+	digest := NotificationDigest(body)
+
+	if header.Get(NotificationDigestHeader) != digest {
+		return time.Time{}, OperationError{Message: "notification digest does not match"}
+	}
+
+	seconds, error := strconv.ParseInt(header.Get(NotificationTimestampHeader), 10, 64)
+
+	if error != nil {
+		return time.Time{}, OperationError{Message: "notification timestamp is not valid"}
+	}
+
+	expected := NotificationSignature(r.secret, header.Get(NotificationTimestampHeader), digest)
+
+	if !hmac.Equal([]byte(header.Get(NotificationSignatureHeader)), []byte(expected)) {
+		return time.Time{}, OperationError{Message: "notification signature does not match"}
+	}
+
+	r.mutex.Lock()
+	now, tolerance := r.clock.Now(), r.tolerance
+	r.mutex.Unlock()
+
+	timestamp := time.Unix(seconds, 0)
+
+	if timestamp.Before(now.Add(-tolerance)) || timestamp.After(now.Add(tolerance)) {
+		return time.Time{}, OperationError{Message: "notification timestamp is outside the tolerance"}
+	}
+
+	return timestamp, nil
+}
+
+// accept records a notification as received, returning its handlers. It is not accepted if it was already received.
+//
+// Notifications older than the tolerance are forgotten, since they are rejected by their timestamp anyway.
+func (r *NotificationReceiver) accept(notification *Notification, timestamp time.Time) ([]NotificationHandler, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	oldest := r.clock.Now().Add(-r.tolerance)
+
+	for id, received := range r.received {
+		if received.Before(oldest) {
+			delete(r.received, id)
+		}
+	}
+
+	if _, exists := r.received[notification.ID]; exists {
+		return nil, false
+	}
+
+	r.received[notification.ID] = timestamp
+
+	return append([]NotificationHandler{}, r.handlers[notificationKey(notification.RecordType, notification.EventType)]...), true
+}
+
+// forget allows a notification that could not be handled to be received again.
+func (r *NotificationReceiver) forget(id string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.received, id)
+}
+
+// NotificationDigest returns the value of the digest header of a notification body.
+func NotificationDigest(body []byte) string {
+	sum := sha256.Sum256(body)
+
+	return fmt.Sprintf("SHA-256=%s", base64.StdEncoding.EncodeToString(sum[:]))
+}
+
+// NotificationSignature returns the value of the signature header of a notification, given its timestamp and digest headers.
+func NotificationSignature(secret []byte, timestamp string, digest string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(fmt.Sprintf("%s\n%s", timestamp, digest)))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// notificationKey identifies the handlers of a record and event type.
+func notificationKey(recordType RecordType, eventType EventType) string {
+	return fmt.Sprintf("%s/%s", recordType, eventType)
+}
+
+// typedNotificationHandler decodes the data of a notification and builds a typed event before calling the handler.
+func typedNotificationHandler[T any, E any](build func(*Notification, *T) *E, handler func(context.Context, *E) error) NotificationHandler {
+	return func(ctx context.Context, notification *Notification) error {
+		if len(notification.Data) == 0 || string(notification.Data) == "null" {
+			return ValidationError{Field: "data", Message: "is required"}
+		}
+
+		data := new(T)
+		error := json.Unmarshal(notification.Data, data)
+
+		if error != nil {
+			return ValidationError{Field: "data", Message: fmt.Sprintf("could not be decoded: %s", error.Error())}
+		}
+
+		return handler(ctx, build(notification, data))
+	}
+}
+
+func newAccountEvent(notification *Notification, data *AccountData) *AccountEvent {
+	return &AccountEvent{Notification: notification, Account: data}
+}
+
+func newPaymentEvent(notification *Notification, data *PaymentData) *PaymentEvent {
+	return &PaymentEvent{Notification: notification, Payment: data}
+}
+
+func newPaymentSubmissionEvent(notification *Notification, data *PaymentSubmissionData) *PaymentSubmissionEvent {
+	return &PaymentSubmissionEvent{Notification: notification, Submission: data, Status: submissionStatus(data)}
+}
@@ -0,0 +1,242 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
+	"github.com/stretchr/testify/assert"
+)
+
+var notificationSecret = []byte("shared-secret")
+
+var notificationTime = time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func newNotificationReceiver() *form3.NotificationReceiver {
+	return form3.NewNotificationReceiver(notificationSecret).WithClock(form3test.NewFakeClock(notificationTime))
+}
+
+func deliver(t *testing.T, receiver http.Handler, request *http.Request) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	receiver.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func newAccountNotification(t *testing.T, id string, eventType form3.EventType, timestamp time.Time) *http.Request {
+	request, error := form3test.NewNotificationRequest("/notifications", notificationSecret, timestamp, form3.Notification{
+		ID:             id,
+		OrganisationID: parentOrganisationID,
+		EventType:      eventType,
+		RecordType:     form3.RecordTypeAccounts,
+	}, form3.AccountData{ID: "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", OrganisationID: parentOrganisationID, Type: "accounts"})
+
+	assert.Nil(t, error)
+
+	return request
+}
+
+func TestNotificationReceiver(t *testing.T) {
+	t.Run("should dispatch typed events to their handlers", func(t *testing.T) {
+		t.Parallel()
+
+		created, updated := []*form3.AccountEvent{}, []*form3.AccountEvent{}
+		receiver := newNotificationReceiver().
+			OnAccountCreated(func(ctx context.Context, event *form3.AccountEvent) error {
+				created = append(created, event)
+				return nil
+			}).
+			OnAccountUpdated(func(ctx context.Context, event *form3.AccountEvent) error {
+				updated = append(updated, event)
+				return nil
+			})
+
+		response := deliver(t, receiver, newAccountNotification(t, "3c9d1b4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e", form3.EventTypeCreated, notificationTime))
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+		assert.Len(t, created, 1)
+		assert.Empty(t, updated)
+		assert.Equal(t, "3c9d1b4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e", created[0].ID)
+		assert.Equal(t, form3.EventTypeCreated, created[0].EventType)
+		assert.Equal(t, "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", created[0].Account.ID)
+	})
+
+	t.Run("should dispatch the status of payment submissions", func(t *testing.T) {
+		t.Parallel()
+
+		statuses := []string{}
+		receiver := newNotificationReceiver().OnPaymentSubmissionStatus(func(ctx context.Context, event *form3.PaymentSubmissionEvent) error {
+			statuses = append(statuses, event.Status)
+			return nil
+		})
+
+		for index, status := range []string{form3.PaymentSubmissionQueuedForDelivery, form3.PaymentSubmissionDeliveryConfirmed} {
+			eventType := form3.EventTypeCreated
+
+			if index > 0 {
+				eventType = form3.EventTypeUpdated
+			}
+
+			request, error := form3test.NewNotificationRequest("/notifications", notificationSecret, notificationTime, form3.Notification{
+				ID:         []string{"8b0c2f3a-1d2e-4f5a-9b6c-7d8e9f0a1b2c", "9c1d3a4b-2e3f-4a5b-8c7d-6e5f4a3b2c1d"}[index],
+				EventType:  eventType,
+				RecordType: form3.RecordTypePaymentSubmissions,
+			}, form3.PaymentSubmissionData{OrganisationResourceFields: form3.OrganisationResourceFields{ID: "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f8a"}, Attributes: &form3.PaymentSubmissionAttributes{Status: status}})
+
+			assert.Nil(t, error)
+			assert.Equal(t, http.StatusNoContent, deliver(t, receiver, request).Code)
+		}
+
+		assert.Equal(t, []string{form3.PaymentSubmissionQueuedForDelivery, form3.PaymentSubmissionDeliveryConfirmed}, statuses)
+	})
+
+	t.Run("should acknowledge notifications without handlers", func(t *testing.T) {
+		t.Parallel()
+
+		response := deliver(t, newNotificationReceiver(), newAccountNotification(t, "3c9d1b4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e", form3.EventTypeDeleted, notificationTime))
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+	})
+
+	t.Run("should reject notifications that are not correctly signed", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name    string
+			tamper  func(request *http.Request)
+			message string
+		}{
+			{"digest", func(r *http.Request) {
+				r.Header.Set(form3.NotificationDigestHeader, form3.NotificationDigest([]byte("{}")))
+			}, "notification digest does not match\n"},
+			{"timestamp", func(r *http.Request) { r.Header.Set(form3.NotificationTimestampHeader, "yesterday") }, "notification timestamp is not valid\n"},
+			{"signature", func(r *http.Request) {
+				r.Header.Set(form3.NotificationSignatureHeader, form3.NotificationSignature([]byte("other-secret"), r.Header.Get(form3.NotificationTimestampHeader), r.Header.Get(form3.NotificationDigestHeader)))
+			}, "notification signature does not match\n"},
+		}
+
+		for _, test := range tests {
+			handled := false
+			receiver := newNotificationReceiver().OnAccountCreated(func(ctx context.Context, event *form3.AccountEvent) error {
+				handled = true
+				return nil
+			})
+			request := newAccountNotification(t, "3c9d1b4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e", form3.EventTypeCreated, notificationTime)
+			test.tamper(request)
+
+			response := deliver(t, receiver, request)
+
+			assert.Equal(t, http.StatusUnauthorized, response.Code, test.name)
+			assert.Equal(t, test.message, response.Body.String(), test.name)
+			assert.False(t, handled, test.name)
+		}
+	})
+
+	t.Run("should reject notifications outside the tolerance", func(t *testing.T) {
+		t.Parallel()
+
+		receiver := newNotificationReceiver().WithTolerance(time.Minute)
+
+		for _, timestamp := range []time.Time{notificationTime.Add(-2 * time.Minute), notificationTime.Add(2 * time.Minute)} {
+			response := deliver(t, receiver, newAccountNotification(t, "3c9d1b4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e", form3.EventTypeCreated, timestamp))
+
+			assert.Equal(t, http.StatusUnauthorized, response.Code)
+			assert.Equal(t, "notification timestamp is outside the tolerance\n", response.Body.String())
+		}
+
+		response := deliver(t, receiver, newAccountNotification(t, "3c9d1b4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e", form3.EventTypeCreated, notificationTime.Add(-time.Minute)))
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+	})
+
+	t.Run("should reject replayed notifications", func(t *testing.T) {
+		t.Parallel()
+
+		handled := 0
+		receiver := newNotificationReceiver().OnAccountCreated(func(ctx context.Context, event *form3.AccountEvent) error {
+			handled++
+			return nil
+		})
+
+		first := deliver(t, receiver, newAccountNotification(t, "3c9d1b4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e", form3.EventTypeCreated, notificationTime))
+		replayed := deliver(t, receiver, newAccountNotification(t, "3c9d1b4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e", form3.EventTypeCreated, notificationTime.Add(time.Second)))
+
+		assert.Equal(t, http.StatusNoContent, first.Code)
+		assert.Equal(t, http.StatusConflict, replayed.Code)
+		assert.Equal(t, "notification 3c9d1b4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e was already received\n", replayed.Body.String())
+		assert.Equal(t, 1, handled)
+	})
+
+	t.Run("should accept a notification again if it could not be handled", func(t *testing.T) {
+		t.Parallel()
+
+		failures := 1
+		receiver := newNotificationReceiver().OnAccountCreated(func(ctx context.Context, event *form3.AccountEvent) error {
+			if failures > 0 {
+				failures--
+				return errors.New("database unavailable")
+			}
+
+			return nil
+		})
+
+		failed := deliver(t, receiver, newAccountNotification(t, "3c9d1b4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e", form3.EventTypeCreated, notificationTime))
+		retried := deliver(t, receiver, newAccountNotification(t, "3c9d1b4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e", form3.EventTypeCreated, notificationTime))
+
+		assert.Equal(t, http.StatusInternalServerError, failed.Code)
+		assert.Equal(t, http.StatusNoContent, retried.Code)
+	})
+
+	t.Run("should reject notifications that cannot be decoded", func(t *testing.T) {
+		t.Parallel()
+
+		request := httptest.NewRequest(http.MethodPost, "/notifications", nil)
+		form3test.SignNotification(request.Header, notificationSecret, notificationTime, []byte{})
+
+		response := deliver(t, newNotificationReceiver(), request)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+
+		response = deliver(t, newNotificationReceiver(), httptest.NewRequest(http.MethodGet, "/notifications", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, response.Code)
+	})
+
+	t.Run("should reject notifications whose data is missing or cannot be decoded", func(t *testing.T) {
+		t.Parallel()
+
+		handled := 0
+		receiver := newNotificationReceiver().OnAccountCreated(func(ctx context.Context, event *form3.AccountEvent) error {
+			handled++
+			return nil
+		})
+
+		notification := form3.Notification{ID: "3c9d1b4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e", EventType: form3.EventTypeCreated, RecordType: form3.RecordTypeAccounts}
+		missing, error := form3test.NewNotificationRequest("/notifications", notificationSecret, notificationTime, notification, nil)
+		assert.Nil(t, error)
+		invalid, error := form3test.NewNotificationRequest("/notifications", notificationSecret, notificationTime, notification, "closed")
+		assert.Nil(t, error)
+
+		response := deliver(t, receiver, missing)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Equal(t, "notification 3c9d1b4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e data is required\n", response.Body.String())
+
+		response = deliver(t, receiver, invalid)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Contains(t, response.Body.String(), "notification 3c9d1b4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e data could not be decoded")
+
+		response = deliver(t, receiver, newAccountNotification(t, "3c9d1b4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e", form3.EventTypeCreated, notificationTime))
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+		assert.Equal(t, 1, handled)
+	})
+}
@@ -0,0 +1,106 @@
+package form3
+
+import "fmt"
+
+// organisationOwned is implemented by the data of every FORM3 resource that belongs to an organisation.
+type organisationOwned interface {
+	ResourceOrganisationID() string
+}
+
+// organisationAssignable is implemented by pointers to the data of every FORM3 resource that belongs to an organisation.
+type organisationAssignable interface {
+	SetResourceOrganisationID(organisationId string)
+}
+
+// OrganisationClient is a view of a client whose services only access the resources of a single organisation.
+//
+// Created resources belong to the organisation, listed resources are filtered by it and fetched, updated or deleted resources
+// that belong to a different organisation are rejected. The organisation service fetches the organisation itself
+// and creates and lists its child units.
+//
+// Only the services that can be scoped are available, the client it was created from gives access to every other one.
+type OrganisationClient struct {
+	OrganisationID      string                      // ID of the organisation whose resources are accessed.
+	Accounts            *AccountService             // Account Service, has access to operations.
+	Payments            *PaymentService             // Payment Service, has access to operations.
+	Mandates            *MandateService             // Mandate Service, has access to operations.
+	DirectDebits        *DirectDebitService         // Direct Debit Service, has access to operations.
+	ConfirmationOfPayee *ConfirmationOfPayeeService // Confirmation of Payee Service, has access to operations.
+	Organisations       *OrganisationService        // Organisation Service, has access to operations.
+	Subscriptions       *SubscriptionService        // Subscription Service, has access to operations.
+	Users               *UserService                // User Service, has access to operations.
+	Roles               *RoleService                // Role Service, has access to operations.
+}
+
+// ForOrganisation creates a view of the client whose services only access the resources of an organisation.
+//
+// Useful when operating many organisations, so that the organisation ID does not have to be set on every resource.
+func (c *Client) ForOrganisation(organisationId string) *OrganisationClient {
+	return &OrganisationClient{
+		OrganisationID:      organisationId,
+		Accounts:            &AccountService{c.Accounts.forOrganisation(organisationId)},
+		Payments:            &PaymentService{c.Payments.forOrganisation(organisationId)},
+		Mandates:            &MandateService{c.Mandates.forOrganisation(organisationId)},
+		DirectDebits:        &DirectDebitService{c.DirectDebits.forOrganisation(organisationId)},
+		ConfirmationOfPayee: &ConfirmationOfPayeeService{&ImmutableResourceService[NameVerificationData]{resources: c.ConfirmationOfPayee.resources.forOrganisation(organisationId)}},
+		Organisations:       &OrganisationService{c.Organisations.forOrganisation(organisationId)},
+		Subscriptions:       &SubscriptionService{c.Subscriptions.forOrganisation(organisationId)},
+		Users:               &UserService{c.Users.forOrganisation(organisationId)},
+		Roles:               &RoleService{c.Roles.forOrganisation(organisationId)},
+	}
+}
+
+// forOrganisation creates a copy of the service that only accesses the resources of an organisation.
+func (s *ResourceService[T]) forOrganisation(organisationId string) *ResourceService[T] {
+	scoped := *s
+	scoped.organisation = organisationId
+
+	return &scoped
+}
+
+// forOrganisation creates a copy of the service that only accesses the resources of an organisation.
+func (s *SubmittableService[T]) forOrganisation(organisationId string) *SubmittableService[T] {
+	return newSubmittableService(s.resources.forOrganisation(organisationId), s.submissionType)
+}
+
+// stampOrganisation returns a copy of the resource that belongs to the organisation of the service, if any.
+//
+// A ValidationError is returned if the resource already belongs to a different organisation.
+func (s *ResourceService[T]) stampOrganisation(resource *Resource[T]) (*Resource[T], error) {
+	if s.organisation == "" || resource == nil || resource.Data == nil {
+		return resource, nil
+	}
+
+	data := *resource.Data
+	owned, isOwned := any(data).(organisationOwned)
+	assignable, isAssignable := any(&data).(organisationAssignable)
+
+	if !isOwned || !isAssignable {
+		return resource, nil
+	}
+
+	if current := owned.ResourceOrganisationID(); current != "" && current != s.organisation {
+		return nil, ValidationError{Field: "organisation_id", Message: fmt.Sprintf("must be %s", s.organisation)}
+	}
+
+	assignable.SetResourceOrganisationID(s.organisation)
+
+	return &Resource[T]{Data: &data, Links: resource.Links}, nil
+}
+
+// checkOrganisation returns an error if a fetched resource does not belong to the organisation of the service, if any.
+//
+// The organisation itself can be accessed too, even though it belongs to its parent.
+func (s *ResourceService[T]) checkOrganisation(id string, resource *Resource[T]) error {
+	if s.organisation == "" || resource.Data == nil || (*resource.Data).ResourceID() == s.organisation {
+		return nil
+	}
+
+	owned, isOwned := any(*resource.Data).(organisationOwned)
+
+	if isOwned && owned.ResourceOrganisationID() != s.organisation {
+		return OperationError{Message: fmt.Sprintf("%s %s belongs to a different organisation", s.name, id)}
+	}
+
+	return nil
+}
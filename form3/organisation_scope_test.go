@@ -0,0 +1,222 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"testing"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
+	"github.com/stretchr/testify/assert"
+)
+
+const otherOrganisationID = "3b6b2e6c-2d0c-4b4a-9c1e-5d8f7a6b5c4d"
+
+func TestForOrganisation(t *testing.T) {
+	t.Run("should create resources that belong to the organisation", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()))
+		scoped := client.ForOrganisation(parentOrganisationID)
+
+		account := &form3.Account{Data: &form3.AccountData{ID: "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", Type: "accounts"}}
+		created, response, error := scoped.Accounts.Create(account)
+
+		assert.Nil(t, error)
+		assert.Equal(t, 201, response.StatusCode)
+		assert.Equal(t, parentOrganisationID, scoped.OrganisationID)
+		assert.Equal(t, parentOrganisationID, created.Data.OrganisationID)
+		assert.Equal(t, "", account.Data.OrganisationID)
+	})
+
+	t.Run("should not create resources that belong to a different organisation", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()))
+
+		account, _ := form3.NewUKAccount(otherOrganisationID).WithBankID("400300").WithBic("NWBKGB22").WithNames("Samantha Holder").Build()
+		created, response, error := client.ForOrganisation(parentOrganisationID).Accounts.Create(account)
+
+		assert.Nil(t, created)
+		assert.Nil(t, response)
+		assert.Equal(t, form3.ValidationError{Field: "organisation_id", Message: "must be " + parentOrganisationID}, error)
+
+		_, exists := server.Resource("/v1/organisation/accounts/" + account.Data.ID)
+
+		assert.False(t, exists)
+	})
+
+	t.Run("should list only the resources of the organisation", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()))
+
+		first, _ := form3.NewUKAccount(parentOrganisationID).WithBankID("400300").WithBic("NWBKGB22").WithNames("Samantha Holder").Build()
+		other, _ := form3.NewUKAccount(otherOrganisationID).WithBankID("400300").WithBic("NWBKGB22").WithNames("Samantha Holder").Build()
+		second, _ := form3.NewUKAccount(parentOrganisationID).WithBankID("400300").WithBic("NWBKGB22").WithNames("Samantha Holder").Build()
+
+		for _, account := range []*form3.Account{first, other, second} {
+			_, _, error := client.Accounts.Create(account)
+
+			assert.Nil(t, error)
+		}
+
+		accounts, _, error := client.ForOrganisation(parentOrganisationID).Accounts.List(form3.ListOptions{})
+
+		assert.Nil(t, error)
+		assert.Len(t, accounts.Data, 2)
+		assert.Equal(t, first.Data.ID, accounts.Data[0].ID)
+		assert.Equal(t, second.Data.ID, accounts.Data[1].ID)
+
+		accounts, _, error = client.Accounts.List(form3.ListOptions{})
+
+		assert.Nil(t, error)
+		assert.Len(t, accounts.Data, 3)
+	})
+
+	t.Run("should not fetch resources that belong to a different organisation", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()))
+
+		account, _ := form3.NewUKAccount(otherOrganisationID).WithBankID("400300").WithBic("NWBKGB22").WithNames("Samantha Holder").Build()
+		_, _, _ = client.Accounts.Create(account)
+
+		fetched, response, error := client.ForOrganisation(parentOrganisationID).Accounts.Fetch(account.Data.ID)
+
+		assert.Nil(t, fetched)
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, form3.OperationError{Message: "account " + account.Data.ID + " belongs to a different organisation"}, error)
+
+		fetched, _, error = client.ForOrganisation(otherOrganisationID).Accounts.Fetch(account.Data.ID)
+
+		assert.Nil(t, error)
+		assert.Equal(t, account.Data.ID, fetched.Data.ID)
+	})
+
+	t.Run("should scope the sub resources of a payment", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()))
+		scoped := client.ForOrganisation(parentOrganisationID)
+
+		payment, _, error := scoped.Payments.Create(&form3.Payment{Data: &form3.PaymentData{OrganisationResourceFields: form3.OrganisationResourceFields{ID: "4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43", Type: "payments"}}})
+
+		assert.Nil(t, error)
+		assert.Equal(t, parentOrganisationID, payment.Data.OrganisationID)
+
+		reversal, _, error := scoped.Payments.Reversals(payment.Data.ID).Create(&form3.PaymentReversal{Data: &form3.PaymentReversalData{OrganisationResourceFields: form3.OrganisationResourceFields{ID: "7a1e8c3b-1f4d-4c2a-9b8e-6d5f4a3c2b1e", Type: "reversals"}}})
+
+		assert.Nil(t, error)
+		assert.Equal(t, parentOrganisationID, reversal.Data.OrganisationID)
+	})
+
+	t.Run("should fetch the organisation itself", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()))
+
+		child, _, error := client.Organisations.CreateChild(parentOrganisationID, "Tenant")
+		assert.Nil(t, error)
+
+		fetched, _, error := client.ForOrganisation(child.Data.ID).Organisations.Fetch(child.Data.ID)
+
+		assert.Nil(t, error)
+		assert.Equal(t, child.Data.ID, fetched.Data.ID)
+	})
+
+	t.Run("should not delete resources that belong to a different organisation", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()))
+
+		account, _ := form3.NewUKAccount(otherOrganisationID).WithBankID("400300").WithBic("NWBKGB22").WithNames("Samantha Holder").Build()
+		_, _, _ = client.Accounts.Create(account)
+
+		response, error := client.ForOrganisation(parentOrganisationID).Accounts.Delete(account.Data.ID, 0)
+
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, form3.OperationError{Message: "account " + account.Data.ID + " belongs to a different organisation"}, error)
+
+		_, exists := server.Resource("/v1/organisation/accounts/" + account.Data.ID)
+
+		assert.True(t, exists)
+
+		response, error = client.ForOrganisation(otherOrganisationID).Accounts.Delete(account.Data.ID, 0)
+
+		assert.Nil(t, error)
+		assert.Equal(t, 204, response.StatusCode)
+	})
+
+	t.Run("should not update resources that belong to a different organisation", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()))
+
+		account, _ := form3.NewUKAccount(otherOrganisationID).WithBankID("400300").WithBic("NWBKGB22").WithNames("Samantha Holder").Build()
+		_, _, _ = client.Accounts.Create(account)
+
+		changed := &form3.Account{Data: &form3.AccountData{ID: account.Data.ID, Type: "accounts", Attributes: &form3.AccountAttributes{Status: "closed"}}}
+		updated, response, error := client.ForOrganisation(parentOrganisationID).Accounts.Update(changed)
+
+		assert.Nil(t, updated)
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, form3.OperationError{Message: "account " + account.Data.ID + " belongs to a different organisation"}, error)
+
+		stored, _, error := client.Accounts.Fetch(account.Data.ID)
+
+		assert.Nil(t, error)
+		assert.Equal(t, otherOrganisationID, stored.Data.OrganisationID)
+		assert.Equal(t, "", stored.Data.Attributes.Status)
+	})
+
+	t.Run("should not list the children of a different organisation", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()))
+
+		children, response, error := client.ForOrganisation(parentOrganisationID).Organisations.ListChildren(otherOrganisationID, form3.ListOptions{})
+
+		assert.Nil(t, children)
+		assert.Nil(t, response)
+		assert.Equal(t, form3.ValidationError{Field: "organisation_id", Message: "must be " + parentOrganisationID}, error)
+
+		_, _, error = client.Organisations.CreateChild(parentOrganisationID, "Tenant")
+		assert.Nil(t, error)
+
+		children, _, error = client.ForOrganisation(parentOrganisationID).Organisations.ListChildren(parentOrganisationID, form3.ListOptions{})
+
+		assert.Nil(t, error)
+		assert.Len(t, children.Data, 1)
+	})
+
+	t.Run("should only list the account history of the organisation", func(t *testing.T) {
+		t.Parallel()
+
+		server, request, _ := newResourceServer(t, 200, `{"data":[]}`)
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+
+		_, _, error := client.ForOrganisation(parentOrganisationID).Accounts.History("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+		assert.Nil(t, error)
+		assert.Equal(t, parentOrganisationID, request.URL.Query().Get("filter[organisation_id]"))
+	})
+}
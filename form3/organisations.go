@@ -0,0 +1,91 @@
+package form3
+
+import (
+	"context"
+	"fmt"
+)
+
+// organisationsUri contains the path to the organisation resources.
+const organisationsUri string = "/v1/organisation/units"
+
+// organisationType is the JSON:API type of an organisation.
+const organisationType string = "organisations"
+
+// OrganisationService allows access to operations related to organisations.
+//
+// Organisations are units that own resources, like accounts and payments. Every organisation can have child units,
+// which are organisations whose organisation ID is the ID of their parent.
+// Organisations can be created, fetched, listed, updated and deleted.
+//
+// More details available in: https://www.api-docs.form3.tech/api/platform/organisations
+type OrganisationService struct {
+	*ResourceService[OrganisationData]
+}
+
+// Represents a FORM3 organisation.
+//
+// More details available in: https://www.api-docs.form3.tech/api/platform/organisations
+type Organisation = Resource[OrganisationData]
+
+// Represents a FORM3 organisation data.
+type OrganisationData struct {
+	Attributes *OrganisationAttributes `json:"attributes,omitempty"`
+	OrganisationResourceFields
+}
+
+// Represents a FORM3 organisation attributes.
+type OrganisationAttributes struct {
+	Name string `json:"name,omitempty"`
+}
+
+// CreateChild allows one to create an organisation as a child unit of another organisation.
+//
+// A random UUID is used as the organisation ID.
+func (s *OrganisationService) CreateChild(parentId string, name string) (*Organisation, *ResponseMeta, error) {
+	return s.CreateChildWithContext(context.Background(), parentId, name)
+}
+
+// CreateChildWithContext is like CreateChild but the request is cancelled once the provided context is done.
+func (s *OrganisationService) CreateChildWithContext(ctx context.Context, parentId string, name string) (*Organisation, *ResponseMeta, error) {
+	if parentId == "" {
+		return nil, nil, ValidationError{Field: "organisation_id", Message: "is required"}
+	}
+
+	id, error := newUuid()
+
+	if error != nil {
+		return nil, nil, OperationError{Message: error.Error()}
+	}
+
+	return s.CreateWithContext(ctx, &Organisation{
+		Data: &OrganisationData{
+			OrganisationResourceFields: OrganisationResourceFields{ID: id, OrganisationID: parentId, Type: organisationType},
+			Attributes:                 &OrganisationAttributes{Name: name},
+		},
+	})
+}
+
+// ListChildren allows one to list a page of the child units of an organisation.
+func (s *OrganisationService) ListChildren(parentId string, options ListOptions) (*ResourceList[OrganisationData], *ResponseMeta, error) {
+	return s.ListChildrenWithContext(context.Background(), parentId, options)
+}
+
+// ListChildrenWithContext is like ListChildren but the request is cancelled once the provided context is done.
+func (s *OrganisationService) ListChildrenWithContext(ctx context.Context, parentId string, options ListOptions) (*ResourceList[OrganisationData], *ResponseMeta, error) {
+	// A service scoped to an organisation only lists its own children
+	if s.organisation != "" && parentId != s.organisation {
+		return nil, nil, ValidationError{Field: "organisation_id", Message: fmt.Sprintf("must be %s", s.organisation)}
+	}
+
+	filter := map[string]string{}
+
+	for attribute, value := range options.Filter {
+		filter[attribute] = value
+	}
+
+	// The parent takes precedence, so that the children of a different organisation are never listed
+	filter["organisation_id"] = parentId
+	options.Filter = filter
+
+	return s.ListWithContext(ctx, options)
+}
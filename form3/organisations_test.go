@@ -0,0 +1,101 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"testing"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
+	"github.com/stretchr/testify/assert"
+)
+
+const parentOrganisationID = "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb"
+
+func TestOrganisations(t *testing.T) {
+	t.Run("should set up a child unit and its accounts", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()))
+
+		unit, response, error := client.Organisations.CreateChild(parentOrganisationID, "Tenant A")
+
+		assert.Nil(t, error)
+		assert.Equal(t, 201, response.StatusCode)
+		assert.Len(t, unit.Data.ID, 36)
+		assert.Equal(t, parentOrganisationID, unit.Data.OrganisationID)
+		assert.Equal(t, "organisations", unit.Data.Type)
+		assert.Equal(t, "Tenant A", unit.Data.Attributes.Name)
+
+		account, _ := form3.NewUKAccount(unit.Data.ID).WithBankID("400300").WithBic("NWBKGB22").WithNames("Samantha Holder").Build()
+		account, _, error = client.Accounts.Create(account)
+
+		assert.Nil(t, error)
+		assert.Equal(t, unit.Data.ID, account.Data.OrganisationID)
+	})
+
+	t.Run("should list only the child units of an organisation", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()))
+
+		first, _, _ := client.Organisations.CreateChild(parentOrganisationID, "Tenant A")
+		_, _, _ = client.Organisations.CreateChild("3b6b2e6c-2d0c-4b4a-9c1e-5d8f7a6b5c4d", "Tenant B")
+		second, _, _ := client.Organisations.CreateChild(parentOrganisationID, "Tenant C")
+
+		children, _, error := client.Organisations.ListChildren(parentOrganisationID, form3.ListOptions{})
+
+		assert.Nil(t, error)
+		assert.Equal(t, []form3.OrganisationData{*first.Data, *second.Data}, children.Data)
+
+		children, _, error = client.Organisations.ListChildren(parentOrganisationID, form3.ListOptions{Filter: map[string]string{"name": "Tenant C"}})
+
+		assert.Nil(t, error)
+		assert.Equal(t, []form3.OrganisationData{*second.Data}, children.Data)
+
+		children, _, error = client.Organisations.ListChildren(parentOrganisationID, form3.ListOptions{Filter: map[string]string{"organisation_id": "3b6b2e6c-2d0c-4b4a-9c1e-5d8f7a6b5c4d"}})
+
+		assert.Nil(t, error)
+		assert.Equal(t, []form3.OrganisationData{*first.Data, *second.Data}, children.Data)
+	})
+
+	t.Run("should rename and delete a child unit", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()))
+		unit, _, _ := client.Organisations.CreateChild(parentOrganisationID, "Tenant A")
+
+		renamed, _, error := client.Organisations.UpdateWithRetry(unit.Data.ID, func(organisation *form3.Organisation) error {
+			organisation.Data.Attributes.Name = "Tenant A Ltd"
+
+			return nil
+		})
+
+		assert.Nil(t, error)
+		assert.Equal(t, "Tenant A Ltd", renamed.Data.Attributes.Name)
+		assert.Equal(t, int64(1), renamed.Data.Version)
+
+		response, error := client.Organisations.DeleteLatest(unit.Data.ID)
+
+		assert.Nil(t, error)
+		assert.Equal(t, 204, response.StatusCode)
+	})
+
+	t.Run("should not create a child unit without a parent", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := form3.New()
+
+		unit, response, error := client.Organisations.CreateChild("", "Tenant A")
+
+		assert.Nil(t, unit)
+		assert.Nil(t, response)
+		assert.Equal(t, form3.ValidationError{Field: "organisation_id", Message: "is required"}, error)
+	})
+}
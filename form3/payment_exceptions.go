@@ -0,0 +1,108 @@
+package form3
+
+import "fmt"
+
+const (
+	RecallDecisionAccepted = "accepted" // RecallDecisionAccepted means the funds of a recalled payment are going to be returned.
+	RecallDecisionRejected = "rejected" // RecallDecisionRejected means the funds of a recalled payment are not going to be returned.
+)
+
+// PaymentRecallService allows access to operations related to the recalls of a payment.
+//
+// A recall asks the beneficiary bank to return the funds of a payment, which it accepts or rejects with a decision.
+//
+// More details available in: https://www.api-docs.form3.tech/api/schemes/fps-direct/payments/recalls
+type PaymentRecallService struct {
+	*SubmittableService[PaymentRecallData]
+}
+
+// Represents a FORM3 payment return, sent by the beneficiary bank when the funds of a payment cannot be applied.
+//
+// More details available in: https://www.api-docs.form3.tech/api/schemes/fps-direct/payments/returns
+type PaymentReturn = Resource[PaymentReturnData]
+
+// Represents a FORM3 payment return data.
+type PaymentReturnData struct {
+	Attributes *PaymentReturnAttributes `json:"attributes,omitempty"`
+	OrganisationResourceFields
+}
+
+// Represents a FORM3 payment return attributes.
+type PaymentReturnAttributes struct {
+	Amount     string `json:"amount,omitempty"`
+	Currency   string `json:"currency,omitempty"`
+	ReturnCode string `json:"return_code,omitempty"`
+}
+
+// Represents a FORM3 payment reversal, which cancels a payment that was sent.
+//
+// More details available in: https://www.api-docs.form3.tech/api/schemes/fps-direct/payments/reversals
+type PaymentReversal = Resource[PaymentReversalData]
+
+// Represents a FORM3 payment reversal data.
+type PaymentReversalData struct {
+	OrganisationResourceFields
+}
+
+// Represents a FORM3 payment recall.
+//
+// More details available in: https://www.api-docs.form3.tech/api/schemes/fps-direct/payments/recalls
+type PaymentRecall = Resource[PaymentRecallData]
+
+// Represents a FORM3 payment recall data.
+type PaymentRecallData struct {
+	Attributes *PaymentRecallAttributes `json:"attributes,omitempty"`
+	OrganisationResourceFields
+}
+
+// Represents a FORM3 payment recall attributes.
+type PaymentRecallAttributes struct {
+	Reason     string `json:"reason,omitempty"`
+	ReasonCode string `json:"reason_code,omitempty"`
+}
+
+// Represents a FORM3 recall decision, which accepts or rejects a recall.
+//
+// More details available in: https://www.api-docs.form3.tech/api/schemes/fps-direct/payments/recall-decisions
+type RecallDecision = Resource[RecallDecisionData]
+
+// Represents a FORM3 recall decision data.
+type RecallDecisionData struct {
+	Attributes *RecallDecisionAttributes `json:"attributes,omitempty"`
+	OrganisationResourceFields
+}
+
+// Represents a FORM3 recall decision attributes.
+type RecallDecisionAttributes struct {
+	Answer     string `json:"answer,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	ReasonCode string `json:"reason_code,omitempty"`
+}
+
+// Returns allows access to the returns of a payment.
+func (s *PaymentService) Returns(paymentId string) *SubmittableService[PaymentReturnData] {
+	returns := newSubResourceService[PaymentReturnData](s.resources, fmt.Sprintf("%s/returns", paymentId), "payment return")
+
+	return newSubmittableService(returns, returnSubmissionType)
+}
+
+// Reversals allows access to the reversals of a payment.
+func (s *PaymentService) Reversals(paymentId string) *SubmittableService[PaymentReversalData] {
+	reversals := newSubResourceService[PaymentReversalData](s.resources, fmt.Sprintf("%s/reversals", paymentId), "payment reversal")
+
+	return newSubmittableService(reversals, reversalSubmissionType)
+}
+
+// Recalls allows access to the recalls of a payment.
+func (s *PaymentService) Recalls(paymentId string) *PaymentRecallService {
+	recalls := newSubResourceService[PaymentRecallData](s.resources, fmt.Sprintf("%s/recalls", paymentId), "payment recall")
+
+	return &PaymentRecallService{newSubmittableService(recalls, recallSubmissionType)}
+}
+
+// Decisions allows access to the decisions of a recall.
+func (s *PaymentRecallService) Decisions(recallId string) *SubmittableService[RecallDecisionData] {
+	decisions := newSubResourceService[RecallDecisionData](s.resources, fmt.Sprintf("%s/decisions", recallId), "recall decision")
+
+	return newSubmittableService(decisions, recallDecisionSubmissionType)
+}
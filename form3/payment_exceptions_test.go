@@ -0,0 +1,187 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
+	"github.com/stretchr/testify/assert"
+)
+
+const exceptionsOrganisationID = "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb"
+
+func newExceptionsClient(t *testing.T, server *form3test.Server, clock *form3test.FakeClock) (*form3.Client, *form3.Payment) {
+	client := newTestClient(t, server.URL().String(), form3.WithClock(clock))
+
+	payment, _, error := client.Payments.Create(&form3.Payment{
+		Data: &form3.PaymentData{
+			OrganisationResourceFields: form3.OrganisationResourceFields{ID: "4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43", OrganisationID: exceptionsOrganisationID, Type: "payments"},
+			Attributes:                 &form3.PaymentAttributes{Amount: "100.21", Currency: "GBP"},
+		},
+	})
+
+	assert.Nil(t, error)
+
+	return client, payment
+}
+
+func TestPaymentExceptions(t *testing.T) {
+	t.Run("should wait until a payment is delivered", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer().WithSubmissionStatuses(form3.PaymentSubmissionQueuedForDelivery, form3.PaymentSubmissionSubmitted, form3.PaymentSubmissionDeliveryConfirmed)
+		defer server.Close()
+		clock := form3test.NewFakeClock(time.Now()).WithAutoAdvance()
+		client, payment := newExceptionsClient(t, server, clock)
+
+		submission, _, error := client.Payments.Submit(payment)
+		assert.Nil(t, error)
+
+		submission, response, error := client.Payments.WaitForSubmission(context.Background(), payment.Data.ID, submission.Data.ID, time.Second)
+
+		assert.Nil(t, error)
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, form3.PaymentSubmissionDeliveryConfirmed, submission.Data.Attributes.Status)
+		assert.Equal(t, []time.Duration{time.Second}, clock.Waits())
+	})
+
+	t.Run("should return the last submission when the context is done before it is final", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer().WithSubmissionStatuses(form3.PaymentSubmissionQueuedForDelivery)
+		defer server.Close()
+		client, payment := newExceptionsClient(t, server, form3test.NewFakeClock(time.Now()))
+		submission, _, _ := client.Payments.Submit(payment)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		submission, _, error := client.Payments.WaitForSubmission(ctx, payment.Data.ID, submission.Data.ID, time.Second)
+
+		assert.Equal(t, form3.OperationError{Message: "context deadline exceeded"}, error)
+		assert.Equal(t, form3.PaymentSubmissionQueuedForDelivery, submission.Data.Attributes.Status)
+	})
+
+	t.Run("should return and submit a payment", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer().WithSubmissionStatuses(form3.PaymentSubmissionDeliveryConfirmed)
+		defer server.Close()
+		client, payment := newExceptionsClient(t, server, form3test.NewFakeClock(time.Now()))
+		returns := client.Payments.Returns(payment.Data.ID)
+
+		paymentReturn, _, error := returns.Create(&form3.PaymentReturn{
+			Data: &form3.PaymentReturnData{
+				OrganisationResourceFields: form3.OrganisationResourceFields{ID: "5ba6b7a4-93b6-4c2a-b5f4-0d1e2c3f4a5b", OrganisationID: exceptionsOrganisationID, Type: "returns"},
+				Attributes:                 &form3.PaymentReturnAttributes{ReturnCode: "AC01"},
+			},
+		})
+		assert.Nil(t, error)
+
+		submission, _, error := returns.Submit(paymentReturn)
+
+		assert.Nil(t, error)
+		assert.Equal(t, form3.PaymentSubmissionDeliveryConfirmed, submission.Data.Attributes.Status)
+
+		stored, _ := server.Resource("/v1/transaction/payments/4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43/returns/5ba6b7a4-93b6-4c2a-b5f4-0d1e2c3f4a5b/submissions/" + submission.Data.ID)
+		assert.Equal(t, "return_submissions", stored["type"])
+		assert.Equal(t, exceptionsOrganisationID, stored["organisation_id"])
+	})
+
+	t.Run("should reverse and submit a payment", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, payment := newExceptionsClient(t, server, form3test.NewFakeClock(time.Now()))
+		reversals := client.Payments.Reversals(payment.Data.ID)
+
+		reversal, _, error := reversals.Create(&form3.PaymentReversal{
+			Data: &form3.PaymentReversalData{OrganisationResourceFields: form3.OrganisationResourceFields{ID: "9c1d2e3f-4a5b-4c6d-8e7f-0a1b2c3d4e5f", OrganisationID: exceptionsOrganisationID, Type: "reversals"}},
+		})
+		assert.Nil(t, error)
+
+		submission, _, error := reversals.Submit(reversal)
+		assert.Nil(t, error)
+
+		stored, _ := server.Resource("/v1/transaction/payments/4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43/reversals/9c1d2e3f-4a5b-4c6d-8e7f-0a1b2c3d4e5f/submissions/" + submission.Data.ID)
+		assert.Equal(t, "reversal_submissions", stored["type"])
+	})
+
+	t.Run("should recall a payment and submit a decision", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer().WithSubmissionStatuses(form3.PaymentSubmissionDeliveryConfirmed)
+		defer server.Close()
+		client, payment := newExceptionsClient(t, server, form3test.NewFakeClock(time.Now()))
+		recalls := client.Payments.Recalls(payment.Data.ID)
+
+		recall, _, error := recalls.Create(&form3.PaymentRecall{
+			Data: &form3.PaymentRecallData{
+				OrganisationResourceFields: form3.OrganisationResourceFields{ID: "2f3e4d5c-6b7a-4980-a1b2-c3d4e5f6a7b8", OrganisationID: exceptionsOrganisationID, Type: "recalls"},
+				Attributes:                 &form3.PaymentRecallAttributes{Reason: "Duplicate payment", ReasonCode: "DUPL"},
+			},
+		})
+		assert.Nil(t, error)
+
+		_, _, error = recalls.Submit(recall)
+		assert.Nil(t, error)
+
+		decisions := recalls.Decisions(recall.Data.ID)
+		decision, _, error := decisions.Create(&form3.RecallDecision{
+			Data: &form3.RecallDecisionData{
+				OrganisationResourceFields: form3.OrganisationResourceFields{ID: "8a7b6c5d-4e3f-4a1b-9c8d-7e6f5a4b3c2d", OrganisationID: exceptionsOrganisationID, Type: "recall_decisions"},
+				Attributes:                 &form3.RecallDecisionAttributes{Answer: form3.RecallDecisionAccepted},
+			},
+		})
+		assert.Nil(t, error)
+
+		submission, _, error := decisions.Submit(decision)
+		assert.Nil(t, error)
+
+		stored, _ := server.Resource("/v1/transaction/payments/4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43/recalls/2f3e4d5c-6b7a-4980-a1b2-c3d4e5f6a7b8/decisions/8a7b6c5d-4e3f-4a1b-9c8d-7e6f5a4b3c2d/submissions/" + submission.Data.ID)
+		assert.Equal(t, "recall_decision_submissions", stored["type"])
+	})
+
+	t.Run("should not submit an exception without data", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := form3.New()
+
+		submission, _, error := client.Payments.Returns("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43").Submit(&form3.PaymentReturn{})
+
+		assert.Nil(t, submission)
+		assert.Equal(t, form3.OperationError{Message: "payment return data is required"}, error)
+	})
+
+	t.Run("should only allow exceptions and submissions to be created, fetched and listed", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := form3.New()
+		payments := client.Payments
+
+		for _, service := range []any{
+			payments.Submissions("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43"),
+			payments.Returns("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43"),
+			payments.Reversals("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43"),
+			payments.Recalls("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43"),
+			payments.Recalls("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43").Decisions("2f3e4d5c-6b7a-4980-a1b2-c3d4e5f6a7b8"),
+		} {
+			serviceType := reflect.TypeOf(service)
+
+			for _, method := range []string{"Create", "Fetch", "List"} {
+				_, ok := serviceType.MethodByName(method)
+				assert.True(t, ok, serviceType.String()+"."+method)
+			}
+
+			for _, method := range []string{"Update", "UpdateWithRetry", "Delete", "DeleteLatest"} {
+				_, ok := serviceType.MethodByName(method)
+				assert.False(t, ok, serviceType.String()+"."+method)
+			}
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package form3
+
+// paymentsUri contains the path to the payment resources.
+const paymentsUri string = "/v1/transaction/payments"
+
+// JSON:API types of the submissions of payments and of their exceptions.
+const (
+	paymentSubmissionType        string = "payment_submissions"
+	returnSubmissionType         string = "return_submissions"
+	reversalSubmissionType       string = "reversal_submissions"
+	recallSubmissionType         string = "recall_submissions"
+	recallDecisionSubmissionType string = "recall_decision_submissions"
+)
+
+const (
+	PaymentSubmissionValidationPending  = "validation_pending"  // PaymentSubmissionValidationPending means the payment is being validated.
+	PaymentSubmissionReleasedToGateway  = "released_to_gateway" // PaymentSubmissionReleasedToGateway means the payment was sent to the scheme gateway.
+	PaymentSubmissionQueuedForDelivery  = "queued_for_delivery" // PaymentSubmissionQueuedForDelivery means the payment is waiting to be delivered to the scheme.
+	PaymentSubmissionSubmitted          = "submitted"           // PaymentSubmissionSubmitted means the payment was delivered to the scheme.
+	PaymentSubmissionDeliveryConfirmed  = "delivery_confirmed"  // PaymentSubmissionDeliveryConfirmed means the scheme accepted the payment.
+	PaymentSubmissionDeliveryFailed     = "delivery_failed"     // PaymentSubmissionDeliveryFailed means the scheme rejected the payment.
+	PaymentSubmissionValidationRejected = "validation_rejected" // PaymentSubmissionValidationRejected means the payment did not pass validation.
+)
+
+// PaymentService allows access to operations related to payments.
+//
+// Payments can be created, fetched and listed. A payment is only sent once it is submitted.
+//
+// More details available in: https://www.api-docs.form3.tech/api/schemes/fps-direct/payments/payments
+type PaymentService struct {
+	*SubmittableService[PaymentData]
+}
+
+// Represents a FORM3 payment.
+//
+// More details available in: https://www.api-docs.form3.tech/api/schemes/fps-direct/payments/payments
+type Payment = Resource[PaymentData]
+
+// Represents a FORM3 payment data.
+type PaymentData struct {
+	Attributes *PaymentAttributes `json:"attributes,omitempty"`
+	OrganisationResourceFields
+}
+
+// Represents a FORM3 payment attributes.
+type PaymentAttributes struct {
+	Amount               string        `json:"amount,omitempty"`
+	BeneficiaryParty     *PaymentParty `json:"beneficiary_party,omitempty"`
+	Currency             string        `json:"currency,omitempty"`
+	DebtorParty          *PaymentParty `json:"debtor_party,omitempty"`
+	EndToEndReference    string        `json:"end_to_end_reference,omitempty"`
+	NumericReference     string        `json:"numeric_reference,omitempty"`
+	PaymentScheme        string        `json:"payment_scheme,omitempty"`
+	ProcessingDate       string        `json:"processing_date,omitempty"`
+	Reference            string        `json:"reference,omitempty"`
+	SchemePaymentSubType string        `json:"scheme_payment_sub_type,omitempty"`
+	SchemePaymentType    string        `json:"scheme_payment_type,omitempty"`
+	UniqueSchemeID       string        `json:"unique_scheme_id,omitempty"`
+}
+
+// Represents the debtor or the beneficiary of a FORM3 payment.
+type PaymentParty struct {
+	AccountName       string                `json:"account_name,omitempty"`
+	AccountNumber     string                `json:"account_number,omitempty"`
+	AccountNumberCode string                `json:"account_number_code,omitempty"`
+	AccountWith       *PaymentAccountHolder `json:"account_with,omitempty"`
+	Name              string                `json:"name,omitempty"`
+}
+
+// Represents the bank holding the account of a FORM3 payment party.
+type PaymentAccountHolder struct {
+	BankID     string `json:"bank_id,omitempty"`
+	BankIDCode string `json:"bank_id_code,omitempty"`
+}
+
+// Represents a FORM3 submission, which sends a payment or one of its exceptions to the scheme.
+//
+// More details available in: https://www.api-docs.form3.tech/api/schemes/fps-direct/payments/payment-submissions
+type PaymentSubmission = Resource[PaymentSubmissionData]
+
+// Represents a FORM3 submission data.
+type PaymentSubmissionData struct {
+	Attributes *PaymentSubmissionAttributes `json:"attributes,omitempty"`
+	OrganisationResourceFields
+}
+
+// Represents a FORM3 submission attributes.
+type PaymentSubmissionAttributes struct {
+	Status             string `json:"status,omitempty"`
+	StatusReason       string `json:"status_reason,omitempty"`
+	SubmissionDatetime string `json:"submission_datetime,omitempty"`
+}
+
+// PartyFromAccount creates a payment party that references a FORM3 account.
+//
+// The account number, the bank and the first name of the account holder are used.
+func PartyFromAccount(account *Account) (*PaymentParty, error) {
+	if account == nil || account.Data == nil || account.Data.Attributes == nil {
+		return nil, ValidationError{Field: "account", Message: "data is required"}
+	}
+
+	attributes := account.Data.Attributes
+	party := &PaymentParty{
+		AccountNumber: attributes.AccountNumber,
+		AccountWith: &PaymentAccountHolder{
+			BankID:     attributes.BankID,
+			BankIDCode: attributes.BankIDCode,
+		},
+	}
+
+	if attributes.Iban != "" && attributes.AccountNumber == "" {
+		party.AccountNumber = attributes.Iban
+		party.AccountNumberCode = "IBAN"
+	} else {
+		party.AccountNumberCode = "BBAN"
+	}
+
+	if len(attributes.Name) > 0 {
+		party.AccountName = attributes.Name[0]
+		party.Name = attributes.Name[0]
+	}
+
+	return party, nil
+}
@@ -0,0 +1,153 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayments(t *testing.T) {
+	t.Run("should create a payment between two accounts", func(t *testing.T) {
+		t.Parallel()
+
+		server, request, body := newResourceServer(t, 201, `{"data":{"id":"4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43","type":"payments","attributes":{"amount":"100.21","currency":"GBP"}}}`)
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+		debtor, _ := form3.NewUKAccount("743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb").WithBankID("400300").WithBic("NWBKGB22").WithAccountNumber("41426819").WithNames("Samantha Holder").Build()
+		beneficiary, _ := form3.NewUKAccount("743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb").WithBankID("400302").WithBic("NWBKGB22").WithAccountNumber("31926819").WithNames("Wilfred Owens").Build()
+		debtorParty, _ := form3.PartyFromAccount(debtor)
+		beneficiaryParty, _ := form3.PartyFromAccount(beneficiary)
+
+		payment, response, error := client.Payments.Create(&form3.Payment{
+			Data: &form3.PaymentData{
+				OrganisationResourceFields: form3.OrganisationResourceFields{ID: "4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43", OrganisationID: "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb", Type: "payments"},
+				Attributes: &form3.PaymentAttributes{
+					Amount:           "100.21",
+					Currency:         "GBP",
+					DebtorParty:      debtorParty,
+					BeneficiaryParty: beneficiaryParty,
+					PaymentScheme:    "FPS",
+					Reference:        "Payment for Em's piano lessons",
+				},
+			},
+		})
+
+		assert.Nil(t, error)
+		assert.Equal(t, 201, response.StatusCode)
+		assert.Equal(t, "/v1/transaction/payments", request.URL.Path)
+		assert.Equal(t, "100.21", payment.Data.Attributes.Amount)
+		assert.JSONEq(t, `{"data":{
+			"id":"4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43",
+			"organisation_id":"743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb",
+			"type":"payments",
+			"attributes":{
+				"amount":"100.21",
+				"currency":"GBP",
+				"debtor_party":{"account_name":"Samantha Holder","account_number":"41426819","account_number_code":"BBAN","account_with":{"bank_id":"400300","bank_id_code":"GBDSC"},"name":"Samantha Holder"},
+				"beneficiary_party":{"account_name":"Wilfred Owens","account_number":"31926819","account_number_code":"BBAN","account_with":{"bank_id":"400302","bank_id_code":"GBDSC"},"name":"Wilfred Owens"},
+				"payment_scheme":"FPS",
+				"reference":"Payment for Em's piano lessons"
+			}
+		}}`, string(*body))
+	})
+
+	t.Run("should submit a payment using its organisation", func(t *testing.T) {
+		t.Parallel()
+
+		server, request, body := newResourceServer(t, 201, `{"data":{"id":"7b8e6a5c-2f7a-4c0b-9d9a-0c6f3f1d2e4b","type":"payment_submissions","attributes":{"status":"accepted"}}}`)
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+
+		submission, _, error := client.Payments.Submit(&form3.Payment{Data: &form3.PaymentData{OrganisationResourceFields: form3.OrganisationResourceFields{ID: "4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43", OrganisationID: "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb"}}})
+
+		sent := form3.PaymentSubmission{}
+		_ = json.Unmarshal(*body, &sent)
+
+		assert.Nil(t, error)
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "/v1/transaction/payments/4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43/submissions", request.URL.Path)
+		assert.Len(t, sent.Data.ID, 36)
+		assert.Equal(t, "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb", sent.Data.OrganisationID)
+		assert.Equal(t, "payment_submissions", sent.Data.Type)
+		assert.Equal(t, "accepted", submission.Data.Attributes.Status)
+	})
+
+	t.Run("should not submit a payment without data", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := form3.New()
+
+		submission, response, error := client.Payments.Submit(&form3.Payment{})
+
+		assert.Nil(t, submission)
+		assert.Nil(t, response)
+		assert.Equal(t, form3.OperationError{Message: "payment data is required"}, error)
+	})
+
+	t.Run("should fetch a payment submission", func(t *testing.T) {
+		t.Parallel()
+
+		server, request, _ := newResourceServer(t, 200, `{"data":{"id":"7b8e6a5c-2f7a-4c0b-9d9a-0c6f3f1d2e4b","attributes":{"status":"delivery_confirmed"}}}`)
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+
+		submission, _, error := client.Payments.Submissions("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43").Fetch("7b8e6a5c-2f7a-4c0b-9d9a-0c6f3f1d2e4b")
+
+		assert.Nil(t, error)
+		assert.Equal(t, "/v1/transaction/payments/4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43/submissions/7b8e6a5c-2f7a-4c0b-9d9a-0c6f3f1d2e4b", request.URL.Path)
+		assert.Equal(t, form3.PaymentSubmissionDeliveryConfirmed, submission.Data.Attributes.Status)
+	})
+
+	t.Run("should only allow payments to be created, fetched and listed", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := form3.New()
+		service := reflect.TypeOf(client.Payments)
+
+		for _, method := range []string{"Create", "Fetch", "List", "Submit"} {
+			_, ok := service.MethodByName(method)
+			assert.True(t, ok, method)
+		}
+
+		for _, method := range []string{"Update", "UpdateWithRetry", "Delete", "DeleteLatest"} {
+			_, ok := service.MethodByName(method)
+			assert.False(t, ok, method)
+		}
+	})
+}
+
+func TestPayments_PartyFromAccount(t *testing.T) {
+	tests := []struct {
+		description string
+		account     *form3.Account
+		expected    *form3.PaymentParty
+		error       error
+	}{
+		{
+			description: "should use the IBAN when there is no account number",
+			account:     &form3.Account{Data: &form3.AccountData{Attributes: &form3.AccountAttributes{Iban: "NL91ABNA0417164300", Name: []string{"Jan Jansen", "J. Jansen"}}}},
+			expected:    &form3.PaymentParty{AccountName: "Jan Jansen", AccountNumber: "NL91ABNA0417164300", AccountNumberCode: "IBAN", AccountWith: &form3.PaymentAccountHolder{}, Name: "Jan Jansen"},
+		},
+		{
+			description: "should not create a party without account data",
+			account:     &form3.Account{},
+			error:       form3.ValidationError{Field: "account", Message: "data is required"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			party, error := form3.PartyFromAccount(test.account)
+
+			assert.Equal(t, test.expected, party)
+			assert.Equal(t, test.error, error)
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package form3
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	RateLimitLimitHeader     = "X-Ratelimit-Limit"     // RateLimitLimitHeader contains how many requests can be performed in the current window.
+	RateLimitRemainingHeader = "X-Ratelimit-Remaining" // RateLimitRemainingHeader contains how many requests can still be performed in the current window.
+	RateLimitResetHeader     = "X-Ratelimit-Reset"     // RateLimitResetHeader contains how many seconds are left until the current window resets.
+	RetryAfterHeader         = "Retry-After"           // RetryAfterHeader contains how many seconds should be waited before performing another request.
+)
+
+// RateLimitMode defines what happens when a request is performed but the rate limit was reached.
+type RateLimitMode int
+
+const (
+	RateLimitWait RateLimitMode = iota // RateLimitWait waits until the request can be performed or the context is done.
+	RateLimitFail                      // RateLimitFail does not wait and returns an error right away.
+)
+
+// RateLimit defines how many requests can be performed.
+type RateLimit struct {
+	RequestsPerSecond float64 // How many requests can be performed per second on average.
+	Burst             int     // How many requests can be performed at once. At least one request is allowed.
+}
+
+// RateLimiter limits the requests performed by a client, using token buckets.
+//
+// There is a global limit and optionally a limit per operation, identified by the http method.
+// The global limit is adapted using the rate limit headers sent by the server.
+//
+// It is safe to be used by multiple goroutines.
+type RateLimiter struct {
+	mutex      sync.Mutex
+	clock      Clock
+	mode       RateLimitMode
+	global     *tokenBucket
+	operations map[string]*tokenBucket
+}
+
+// NewRateLimiter creates a new rate limiter with a global limit.
+func NewRateLimiter(limit RateLimit, mode RateLimitMode) *RateLimiter {
+	return &RateLimiter{
+		clock:      systemClock{},
+		mode:       mode,
+		global:     newTokenBucket(limit, time.Now()),
+		operations: map[string]*tokenBucket{},
+	}
+}
+
+// WithClock sets the clock used to refill the token buckets and to wait for them.
+func (l *RateLimiter) WithClock(clock Clock) *RateLimiter {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := clock.Now()
+	l.clock = clock
+	l.global.last = now
+
+	for _, bucket := range l.operations {
+		bucket.last = now
+	}
+
+	return l
+}
+
+// WithOperationLimit adds a limit to the requests performed using a given http method, on top of the global limit.
+func (l *RateLimiter) WithOperationLimit(method string, limit RateLimit) *RateLimiter {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.operations[method] = newTokenBucket(limit, l.clock.Now())
+
+	return l
+}
+
+// Wait blocks until a request using the given http method can be performed.
+//
+// An error is returned right away if the limiter does not wait or if the request could not be performed before the context is done.
+func (l *RateLimiter) Wait(ctx context.Context, method string) error {
+	l.mutex.Lock()
+
+	now := l.clock.Now()
+	buckets := []*tokenBucket{l.global}
+
+	if operation, ok := l.operations[method]; ok {
+		buckets = append(buckets, operation)
+	}
+
+	delay := time.Duration(0)
+
+	for _, bucket := range buckets {
+		if wait := bucket.reserve(now); wait > delay {
+			delay = wait
+		}
+	}
+
+	// Context deadlines are in real time, even when the limiter waits using a different clock
+	deadline, hasDeadline := ctx.Deadline()
+
+	if delay > 0 && (l.mode == RateLimitFail || (hasDeadline && delay > time.Until(deadline))) {
+		for _, bucket := range buckets {
+			bucket.cancel()
+		}
+
+		l.mutex.Unlock()
+
+		return OperationError{Message: fmt.Sprintf("rate limit exceeded, next request available in %v", delay)}
+	}
+
+	l.mutex.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+
+		for _, bucket := range buckets {
+			bucket.cancel()
+		}
+
+		return OperationError{Message: ctx.Err().Error()}
+	case <-l.clock.After(delay):
+		return nil
+	}
+}
+
+// adapt changes the global limit using the rate limit headers of a response.
+func (l *RateLimiter) adapt(response *http.Response) {
+	if response == nil {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.clock.Now()
+
+	if retryAfter, ok := headerNumber(response.Header, RetryAfterHeader); ok && response.StatusCode == http.StatusTooManyRequests {
+		l.global.pause(now, time.Duration(retryAfter*float64(time.Second)))
+	}
+
+	remaining, hasRemaining := headerNumber(response.Header, RateLimitRemainingHeader)
+	reset, hasReset := headerNumber(response.Header, RateLimitResetHeader)
+
+	if hasRemaining && hasReset {
+		l.global.adapt(now, int(remaining), time.Duration(reset*float64(time.Second)))
+	}
+}
+
+// headerNumber parses a header containing a positive number.
+func headerNumber(header http.Header, name string) (float64, bool) {
+	value, error := strconv.ParseFloat(header.Get(name), 64)
+
+	if error != nil || value < 0 {
+		return 0, false
+	}
+
+	return value, true
+}
+
+// tokenBucket allows requests as long as there are tokens, which are refilled at a given rate.
+//
+// Tokens can go below zero, which means that requests are waiting for them to be refilled.
+type tokenBucket struct {
+	limit       RateLimit // Configured limit.
+	rate        float64   // Current rate, can be lower than the configured rate when adapted.
+	tokens      float64   // Available tokens.
+	last        time.Time // Last time the tokens were refilled.
+	pausedUntil time.Time // No tokens are refilled until this time.
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+
+	return &tokenBucket{limit: limit, rate: limit.RequestsPerSecond, tokens: float64(limit.Burst), last: now}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if now.Before(b.pausedUntil) {
+		b.last = now
+
+		return
+	}
+
+	if b.last.Before(b.pausedUntil) {
+		b.last = b.pausedUntil
+	}
+
+	if b.rate <= 0 {
+		b.tokens = float64(b.limit.Burst)
+	} else {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+
+	b.last = now
+}
+
+// reserve takes a token and returns how much time is left until it is available.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.refill(now)
+	b.tokens--
+
+	wait := time.Duration(0)
+
+	if now.Before(b.pausedUntil) {
+		wait = b.pausedUntil.Sub(now)
+	}
+
+	if b.tokens < 0 && b.rate > 0 {
+		wait += time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+
+	return wait
+}
+
+// cancel gives back a token that was reserved but not used.
+func (b *tokenBucket) cancel() {
+	b.tokens = math.Min(float64(b.limit.Burst), b.tokens+1)
+}
+
+// pause stops refilling tokens for some time. A single token is available once the pause ends.
+func (b *tokenBucket) pause(now time.Time, duration time.Duration) {
+	b.refill(now)
+
+	if until := now.Add(duration); until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+
+	b.tokens = math.Min(b.tokens, 1)
+}
+
+// adapt spreads the requests the server still allows over the time left until its window resets.
+func (b *tokenBucket) adapt(now time.Time, remaining int, reset time.Duration) {
+	b.refill(now)
+
+	if remaining <= 0 {
+		b.pause(now, reset)
+
+		return
+	}
+
+	b.tokens = math.Min(b.tokens, float64(remaining))
+	b.rate = b.limit.RequestsPerSecond
+
+	if reset > 0 {
+		serverRate := float64(remaining) / reset.Seconds()
+
+		if b.rate <= 0 || serverRate < b.rate {
+			b.rate = serverRate
+		}
+	}
+}
@@ -0,0 +1,141 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	t.Run("should allow a burst of requests and then wait for the next request", func(t *testing.T) {
+		t.Parallel()
+
+		clock := form3test.NewFakeClock(time.Now()).WithAutoAdvance()
+		limiter := form3.NewRateLimiter(form3.RateLimit{RequestsPerSecond: 20, Burst: 2}, form3.RateLimitWait).WithClock(clock)
+
+		assert.Nil(t, limiter.Wait(context.Background(), http.MethodGet))
+		assert.Nil(t, limiter.Wait(context.Background(), http.MethodGet))
+		assert.Empty(t, clock.Waits())
+
+		assert.Nil(t, limiter.Wait(context.Background(), http.MethodGet))
+		assert.Equal(t, []time.Duration{50 * time.Millisecond}, clock.Waits())
+	})
+
+	t.Run("should fail right away when the limiter does not wait", func(t *testing.T) {
+		t.Parallel()
+
+		limiter := form3.NewRateLimiter(form3.RateLimit{RequestsPerSecond: 1, Burst: 1}, form3.RateLimitFail)
+
+		assert.Nil(t, limiter.Wait(context.Background(), http.MethodGet))
+		assert.ErrorContains(t, limiter.Wait(context.Background(), http.MethodGet), "rate limit exceeded")
+	})
+
+	t.Run("should fail right away when the request cannot be performed before the context deadline", func(t *testing.T) {
+		t.Parallel()
+
+		limiter := form3.NewRateLimiter(form3.RateLimit{RequestsPerSecond: 0.1, Burst: 1}, form3.RateLimitWait)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		assert.Nil(t, limiter.Wait(ctx, http.MethodGet))
+
+		start := time.Now()
+
+		assert.ErrorContains(t, limiter.Wait(ctx, http.MethodGet), "rate limit exceeded")
+		assert.Less(t, time.Since(start), 100*time.Millisecond)
+	})
+
+	t.Run("should compare the context deadline with real time", func(t *testing.T) {
+		t.Parallel()
+
+		clock := form3test.NewFakeClock(time.Now().Add(time.Hour)).WithAutoAdvance()
+		limiter := form3.NewRateLimiter(form3.RateLimit{RequestsPerSecond: 0.1, Burst: 1}, form3.RateLimitWait).WithClock(clock)
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Minute))
+		defer cancel()
+
+		assert.Nil(t, limiter.Wait(ctx, http.MethodGet))
+		assert.Nil(t, limiter.Wait(ctx, http.MethodGet))
+		assert.Equal(t, []time.Duration{10 * time.Second}, clock.Waits())
+	})
+
+	t.Run("should stop waiting when the context is cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		clock := form3test.NewFakeClock(time.Now())
+		limiter := form3.NewRateLimiter(form3.RateLimit{RequestsPerSecond: 0.1, Burst: 1}, form3.RateLimitWait).WithClock(clock)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		assert.Nil(t, limiter.Wait(ctx, http.MethodGet))
+
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		assert.Equal(t, form3.OperationError{Message: "context canceled"}, limiter.Wait(ctx, http.MethodGet))
+	})
+
+	t.Run("should limit operations on top of the global limit", func(t *testing.T) {
+		t.Parallel()
+
+		limiter := form3.NewRateLimiter(form3.RateLimit{RequestsPerSecond: 100, Burst: 10}, form3.RateLimitFail).
+			WithOperationLimit(http.MethodDelete, form3.RateLimit{RequestsPerSecond: 1, Burst: 1})
+
+		assert.Nil(t, limiter.Wait(context.Background(), http.MethodDelete))
+		assert.ErrorContains(t, limiter.Wait(context.Background(), http.MethodDelete), "rate limit exceeded")
+		assert.Nil(t, limiter.Wait(context.Background(), http.MethodGet))
+	})
+}
+
+func TestRateLimiter_PerformRequest(t *testing.T) {
+	t.Run("should not perform requests once the server reports that there are no requests remaining", func(t *testing.T) {
+		defer gock.Off()
+		client, _ := form3.New(form3.WithRateLimiter(form3.NewRateLimiter(form3.RateLimit{RequestsPerSecond: 100, Burst: 10}, form3.RateLimitFail)))
+
+		gock.New("http://test:8080").
+			Get("/endpoint").
+			Reply(200).
+			SetHeader(form3.RateLimitRemainingHeader, "0").
+			SetHeader(form3.RateLimitResetHeader, "30")
+
+		response, error := client.PerformRequest("GET", "http://test:8080/endpoint", nil)
+
+		assert.Nil(t, error)
+		assert.Equal(t, 200, response.StatusCode)
+
+		response, error = client.PerformRequest("GET", "http://test:8080/endpoint", nil)
+
+		assert.Nil(t, response)
+		assert.ErrorContains(t, error, "rate limit exceeded")
+	})
+
+	t.Run("should wait as long as the server asks when too many requests were performed", func(t *testing.T) {
+		defer gock.Off()
+		clock := form3test.NewFakeClock(time.Now()).WithAutoAdvance()
+		client, _ := form3.New(
+			form3.WithHttpTimeUntilNextAttempt(50*time.Microsecond),
+			form3.WithClock(form3test.NewFakeClock(time.Now()).WithAutoAdvance()),
+			form3.WithRateLimiter(form3.NewRateLimiter(form3.RateLimit{RequestsPerSecond: 100, Burst: 10}, form3.RateLimitWait).WithClock(clock)),
+		)
+
+		gock.New("http://test:8080").
+			Get("/endpoint").
+			Reply(429).
+			SetHeader(form3.RetryAfterHeader, "0.1")
+
+		gock.New("http://test:8080").
+			Get("/endpoint").
+			Reply(200)
+
+		response, error := client.PerformRequest("GET", "http://test:8080/endpoint", nil)
+
+		assert.Nil(t, error)
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, []time.Duration{100 * time.Millisecond}, clock.Waits())
+	})
+}
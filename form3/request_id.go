@@ -0,0 +1,23 @@
+package form3
+
+import "context"
+
+// RequestAttemptHeader contains the number of the attempt being made to perform a request, starting at 1.
+const RequestAttemptHeader = "X-Request-Attempt"
+
+// requestIDKey is used to store a request ID in a context.
+type requestIDKey struct{}
+
+// WithRequestID returns a context that makes operations use the given request ID instead of generating one.
+//
+// Allows the caller to correlate its own logs with the server logs.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in a context, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+
+	return requestID, ok && requestID != ""
+}
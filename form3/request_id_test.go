@@ -0,0 +1,137 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRequestIDServer creates a server that fails the first requests and records the request headers it receives.
+func newRequestIDServer(t *testing.T, failures int) (*httptest.Server, func() []http.Header) {
+	mutex := sync.Mutex{}
+	headers := []http.Header{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		headers = append(headers, r.Header.Clone())
+		attempt := len(headers)
+		mutex.Unlock()
+
+		w.Header().Set(form3.RequestIDHeader, "server-"+r.Header.Get(form3.RequestIDHeader))
+
+		if attempt <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_message":"record does not exist"}`))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, func() []http.Header {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return headers
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	t.Run("should send the same generated request ID in every attempt", func(t *testing.T) {
+		t.Parallel()
+
+		server, headers := newRequestIDServer(t, 2)
+		client := newTestClient(t, server.URL, form3.WithHttpTimeUntilNextAttempt(time.Millisecond))
+
+		_, response, error := client.Accounts.Fetch("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+		assert.NotNil(t, error)
+		assert.Len(t, headers(), 3)
+		assert.Len(t, response.ClientRequestID, 36)
+
+		for index, header := range headers() {
+			assert.Equal(t, response.ClientRequestID, header.Get(form3.RequestIDHeader))
+			assert.Equal(t, strconv.Itoa(index+1), header.Get(form3.RequestAttemptHeader))
+		}
+	})
+
+	t.Run("should generate a different request ID for every operation", func(t *testing.T) {
+		t.Parallel()
+
+		server, _ := newRequestIDServer(t, 0)
+		client := newTestClient(t, server.URL, form3.WithHttpTimeUntilNextAttempt(time.Millisecond))
+
+		first, _ := client.Accounts.Delete("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", 0)
+		second, _ := client.Accounts.Delete("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", 0)
+
+		assert.NotEqual(t, first.ClientRequestID, second.ClientRequestID)
+	})
+
+	t.Run("should send the request ID provided in the context", func(t *testing.T) {
+		t.Parallel()
+
+		server, headers := newRequestIDServer(t, 1)
+		client := newTestClient(t, server.URL, form3.WithHttpTimeUntilNextAttempt(time.Millisecond))
+		ctx := form3.WithRequestID(context.Background(), "caller-request-id")
+
+		_, response, _ := client.Accounts.FetchWithContext(ctx, "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+		assert.Equal(t, "caller-request-id", response.ClientRequestID)
+		assert.Equal(t, "server-caller-request-id", response.RequestID)
+
+		for _, header := range headers() {
+			assert.Equal(t, "caller-request-id", header.Get(form3.RequestIDHeader))
+		}
+	})
+
+	t.Run("should return the request ID returned by the server in the error", func(t *testing.T) {
+		t.Parallel()
+
+		server, _ := newRequestIDServer(t, 0)
+		client := newTestClient(t, server.URL, form3.WithHttpTimeUntilNextAttempt(time.Millisecond))
+		ctx := form3.WithRequestID(context.Background(), "caller-request-id")
+
+		account, response, error := client.Accounts.FetchWithContext(ctx, "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+		assert.Nil(t, account)
+		assert.Equal(t, 404, response.StatusCode)
+		assert.Equal(t, form3.OperationError{
+			Message:   "404 Not Found",
+			Body:      []byte(`{"error_message":"record does not exist"}`),
+			RequestID: "server-caller-request-id",
+		}, error)
+	})
+
+	t.Run("should keep the request ID in the error when no response was obtained", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := form3.New(form3.WithBaseUrl(&url.URL{Scheme: "asdf", Host: "asdf"}))
+		ctx := form3.WithRequestID(context.Background(), "caller-request-id")
+
+		_, _, error := client.Accounts.FetchWithContext(ctx, "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+		assert.Equal(t, "caller-request-id", error.(form3.OperationError).Meta.ClientRequestID)
+	})
+
+	t.Run("should ignore an empty request ID in the context", func(t *testing.T) {
+		t.Parallel()
+
+		requestID, ok := form3.RequestIDFromContext(form3.WithRequestID(context.Background(), ""))
+
+		assert.Equal(t, "", requestID)
+		assert.False(t, ok)
+	})
+}
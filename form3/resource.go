@@ -0,0 +1,529 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ResourceData is implemented by the data of every FORM3 resource, so that it can be identified.
+type ResourceData interface {
+	ResourceID() string     // Returns the ID of the resource.
+	ResourceVersion() int64 // Returns the version of the resource, used to detect concurrent changes.
+}
+
+// ResourceFields contains the fields of a FORM3 resource that does not belong to an organisation.
+//
+// It is embedded in the data of such resources so that they implement ResourceData, its fields being encoded
+// in json alongside the attributes.
+type ResourceFields struct {
+	ID      string `json:"id,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Version int64  `json:"version,omitempty"`
+}
+
+// OrganisationResourceFields contains the fields of a FORM3 resource that belongs to an organisation.
+//
+// It is embedded in the data of such resources so that they implement ResourceData and can be scoped to an organisation,
+// its fields being encoded in json alongside the attributes.
+type OrganisationResourceFields struct {
+	ID             string `json:"id,omitempty"`
+	OrganisationID string `json:"organisation_id,omitempty"`
+	Type           string `json:"type,omitempty"`
+	Version        int64  `json:"version,omitempty"`
+}
+
+// ResourceID returns the resource ID.
+func (f ResourceFields) ResourceID() string {
+	return f.ID
+}
+
+// ResourceVersion returns the resource version.
+func (f ResourceFields) ResourceVersion() int64 {
+	return f.Version
+}
+
+// ResourceID returns the resource ID.
+func (f OrganisationResourceFields) ResourceID() string {
+	return f.ID
+}
+
+// ResourceVersion returns the resource version.
+func (f OrganisationResourceFields) ResourceVersion() int64 {
+	return f.Version
+}
+
+// ResourceOrganisationID returns the ID of the organisation the resource belongs to.
+func (f OrganisationResourceFields) ResourceOrganisationID() string {
+	return f.OrganisationID
+}
+
+// SetResourceOrganisationID sets the ID of the organisation the resource belongs to.
+func (f *OrganisationResourceFields) SetResourceOrganisationID(organisationId string) {
+	f.OrganisationID = organisationId
+}
+
+// Resource represents a JSON:API document containing a single FORM3 resource.
+//
+// More details available in: https://www.api-docs.form3.tech/api/tutorials/getting-started/api-overview
+type Resource[T any] struct {
+	Data  *T     `json:"data,omitempty"`
+	Links *Links `json:"links,omitempty"`
+}
+
+// ResourceList represents a JSON:API document containing a page of FORM3 resources.
+type ResourceList[T any] struct {
+	Data  []T    `json:"data"`
+	Links *Links `json:"links,omitempty"`
+}
+
+// Links represents the links of a JSON:API document.
+type Links struct {
+	Self  string `json:"self,omitempty"`
+	First string `json:"first,omitempty"`
+	Last  string `json:"last,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+}
+
+// ListOptions allows one to choose which resources are listed.
+type ListOptions struct {
+	PageNumber int               // Page to be listed, starting at 0.
+	PageSize   int               // How many resources are listed per page. The server default is used if not positive.
+	Filter     map[string]string // Only resources whose attributes have the given values are listed.
+}
+
+// Mutate defines the function interface that is used to change a resource before it is updated.
+type Mutate[T any] func(resource *Resource[T]) error
+
+// ResourceService allows access to the operations every FORM3 resource has.
+//
+// Specific resources are thin typed wrappers around it.
+type ResourceService[T ResourceData] struct {
+	Client        *Client       // Used to access basic request configurations and perform http requests.
+	JsonMarshal   JsonMarshal   // Used to marshal json.
+	JsonUnmarshal JsonUnmarshal // Used to unmarshal json.
+	ReadAll       ReadAll       // Used to read the response body of a http request.
+	path          string        // Path to the resource collection.
+	name          string        // Name of the resource, used in messages.
+	organisation  string        // Only resources of this organisation are accessed, if set.
+}
+
+// NewResourceService creates a service for the resources available in a given path, for example "/v1/organisation/accounts".
+//
+// The name is used in messages, for example "account".
+func NewResourceService[T ResourceData](client *Client, path string, name string) *ResourceService[T] {
+	return &ResourceService[T]{
+		Client:        client,
+		JsonMarshal:   json.Marshal,
+		JsonUnmarshal: json.Unmarshal,
+		ReadAll:       io.ReadAll,
+		path:          path,
+		name:          name,
+	}
+}
+
+// newSubResourceService creates a service for the resources that belong to a resource of a parent service.
+//
+// The path is relative to the parent resource collection and the parent configuration is shared.
+func newSubResourceService[S ResourceData, T ResourceData](parent *ResourceService[T], path string, name string) *ResourceService[S] {
+	return &ResourceService[S]{
+		Client:        parent.Client,
+		JsonMarshal:   parent.JsonMarshal,
+		JsonUnmarshal: parent.JsonUnmarshal,
+		ReadAll:       parent.ReadAll,
+		path:          fmt.Sprintf("%s/%s", parent.path, path),
+		name:          name,
+		organisation:  parent.organisation,
+	}
+}
+
+// Path returns the path to the resource collection.
+func (s *ResourceService[T]) Path() string {
+	return s.path
+}
+
+// Create allows one to create a resource.
+func (s *ResourceService[T]) Create(resource *Resource[T]) (*Resource[T], *ResponseMeta, error) {
+	return s.CreateWithContext(context.Background(), resource)
+}
+
+// CreateWithContext is like Create but the request is cancelled once the provided context is done.
+func (s *ResourceService[T]) CreateWithContext(ctx context.Context, resource *Resource[T]) (*Resource[T], *ResponseMeta, error) {
+	requestURL := fmt.Sprintf("%s%s", s.Client.baseUrl, s.path)
+
+	resource, error := s.stampOrganisation(resource)
+
+	if error != nil {
+		return nil, nil, error
+	}
+
+	body, error := s.JsonMarshal(resource)
+
+	if error != nil {
+		return nil, nil, OperationError{Message: error.Error()}
+	}
+
+	return handleResourceResponse[Resource[T]](ctx, s, http.MethodPost, requestURL, body, http.StatusCreated)
+}
+
+// Fetch allows one to fetch a resource.
+func (s *ResourceService[T]) Fetch(id string) (*Resource[T], *ResponseMeta, error) {
+	return s.FetchWithContext(context.Background(), id)
+}
+
+// FetchWithContext is like Fetch but the request is cancelled once the provided context is done.
+func (s *ResourceService[T]) FetchWithContext(ctx context.Context, id string) (*Resource[T], *ResponseMeta, error) {
+	requestURL := fmt.Sprintf("%s%s/%s", s.Client.baseUrl, s.path, id)
+
+	resource, response, error := handleResourceResponse[Resource[T]](ctx, s, http.MethodGet, requestURL, nil, http.StatusOK)
+
+	if error != nil {
+		return nil, response, error
+	}
+
+	error = s.checkOrganisation(id, resource)
+
+	if error != nil {
+		return nil, response, error
+	}
+
+	return resource, response, nil
+}
+
+// List allows one to list a page of resources.
+//
+// The links of the returned list can be used to know if there are more pages.
+func (s *ResourceService[T]) List(options ListOptions) (*ResourceList[T], *ResponseMeta, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but the request is cancelled once the provided context is done.
+func (s *ResourceService[T]) ListWithContext(ctx context.Context, options ListOptions) (*ResourceList[T], *ResponseMeta, error) {
+	query := url.Values{}
+
+	if options.PageNumber > 0 {
+		query.Set("page[number]", strconv.Itoa(options.PageNumber))
+	}
+
+	if options.PageSize > 0 {
+		query.Set("page[size]", strconv.Itoa(options.PageSize))
+	}
+
+	for attribute, value := range options.Filter {
+		query.Set(fmt.Sprintf("filter[%s]", attribute), value)
+	}
+
+	if s.organisation != "" {
+		query.Set("filter[organisation_id]", s.organisation)
+	}
+
+	requestURL := fmt.Sprintf("%s%s", s.Client.baseUrl, s.path)
+
+	if len(query) > 0 {
+		requestURL = fmt.Sprintf("%s?%s", requestURL, query.Encode())
+	}
+
+	return handleResourceResponse[ResourceList[T]](ctx, s, http.MethodGet, requestURL, nil, http.StatusOK)
+}
+
+// Update allows one to update a resource.
+//
+// The resource version must match the current version of the resource, otherwise a conflict is returned.
+func (s *ResourceService[T]) Update(resource *Resource[T]) (*Resource[T], *ResponseMeta, error) {
+	return s.UpdateWithContext(context.Background(), resource)
+}
+
+// UpdateWithContext is like Update but the request is cancelled once the provided context is done.
+func (s *ResourceService[T]) UpdateWithContext(ctx context.Context, resource *Resource[T]) (*Resource[T], *ResponseMeta, error) {
+	if resource == nil || resource.Data == nil {
+		return nil, nil, OperationError{Message: fmt.Sprintf("%s data is required", s.name)}
+	}
+
+	requestURL := fmt.Sprintf("%s%s/%s", s.Client.baseUrl, s.path, (*resource.Data).ResourceID())
+
+	resource, error := s.stampOrganisation(resource)
+
+	if error != nil {
+		return nil, nil, error
+	}
+
+	// A resource of a different organisation must not be updated, so it is fetched first to be checked
+	if s.organisation != "" {
+		_, response, error := s.FetchWithContext(ctx, (*resource.Data).ResourceID())
+
+		if error != nil {
+			return nil, response, error
+		}
+	}
+
+	body, error := s.JsonMarshal(resource)
+
+	if error != nil {
+		return nil, nil, OperationError{Message: error.Error()}
+	}
+
+	return handleResourceResponse[Resource[T]](ctx, s, http.MethodPatch, requestURL, body, http.StatusOK)
+}
+
+// Delete allows one to delete a resource.
+func (s *ResourceService[T]) Delete(id string, version int64) (*ResponseMeta, error) {
+	return s.DeleteWithContext(context.Background(), id, version)
+}
+
+// DeleteWithContext is like Delete but the request is cancelled once the provided context is done.
+func (s *ResourceService[T]) DeleteWithContext(ctx context.Context, id string, version int64) (*ResponseMeta, error) {
+	// A resource of a different organisation must not be deleted, so it is fetched first to be checked
+	if s.organisation != "" {
+		_, response, error := s.FetchWithContext(ctx, id)
+
+		if error != nil {
+			return response, error
+		}
+	}
+
+	return s.delete(ctx, id, version)
+}
+
+// delete deletes a resource without checking its organisation.
+func (s *ResourceService[T]) delete(ctx context.Context, id string, version int64) (*ResponseMeta, error) {
+	requestURL := fmt.Sprintf("%s%s/%s?version=%d", s.Client.baseUrl, s.path, id, version)
+
+	response, error := s.Client.PerformRequestWithContext(ctx, http.MethodDelete, requestURL, nil)
+
+	if error != nil {
+		return nil, error
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent {
+		body, error := s.ReadAll(response.Body)
+
+		if error != nil {
+			return response, OperationError{Message: error.Error()}
+		}
+
+		return response, newResponseError(response, body)
+	}
+
+	return response, nil
+}
+
+// UpdateWithRetry fetches the latest version of a resource, changes it and updates it.
+//
+// If the resource changed in the meantime, it is fetched and changed again. This is repeated as many times as allowed by the client.
+// An error returned when changing the resource stops the operation.
+func (s *ResourceService[T]) UpdateWithRetry(id string, mutate Mutate[T]) (*Resource[T], *ResponseMeta, error) {
+	return s.UpdateWithRetryWithContext(context.Background(), id, mutate)
+}
+
+// UpdateWithRetryWithContext is like UpdateWithRetry but the requests are cancelled once the provided context is done.
+func (s *ResourceService[T]) UpdateWithRetryWithContext(ctx context.Context, id string, mutate Mutate[T]) (*Resource[T], *ResponseMeta, error) {
+	remainingAttempts := s.Client.conflictRetryAttempts
+
+	for {
+		resource, response, error := s.FetchWithContext(ctx, id)
+
+		if error != nil {
+			return nil, response, error
+		}
+
+		error = mutate(resource)
+
+		if error != nil {
+			return nil, response, OperationError{Message: error.Error(), Cause: error}
+		}
+
+		updatedResource, response, error := s.UpdateWithContext(ctx, resource)
+
+		if !s.shouldRetryConflict(id, response, remainingAttempts) {
+			return updatedResource, response, error
+		}
+
+		remainingAttempts--
+	}
+}
+
+// DeleteLatest deletes a resource using its latest version.
+//
+// If the resource changed in the meantime, the latest version is fetched again. This is repeated as many times as allowed by the client.
+func (s *ResourceService[T]) DeleteLatest(id string) (*ResponseMeta, error) {
+	return s.DeleteLatestWithContext(context.Background(), id)
+}
+
+// DeleteLatestWithContext is like DeleteLatest but the requests are cancelled once the provided context is done.
+func (s *ResourceService[T]) DeleteLatestWithContext(ctx context.Context, id string) (*ResponseMeta, error) {
+	remainingAttempts := s.Client.conflictRetryAttempts
+
+	for {
+		resource, response, error := s.FetchWithContext(ctx, id)
+
+		if error != nil {
+			return response, error
+		}
+
+		if resource.Data == nil {
+			return response, OperationError{Message: fmt.Sprintf("%s data is missing", s.name)}
+		}
+
+		response, error = s.delete(ctx, id, (*resource.Data).ResourceVersion())
+
+		if !s.shouldRetryConflict(id, response, remainingAttempts) {
+			return response, error
+		}
+
+		remainingAttempts--
+	}
+}
+
+func (s *ResourceService[T]) shouldRetryConflict(id string, response *ResponseMeta, remainingAttempts int) bool {
+	if response == nil || response.StatusCode != http.StatusConflict || remainingAttempts <= 0 {
+		return false
+	}
+
+	if s.Client.debugEnabled {
+		s.Client.logDebugMessage("DEBUG: Version conflict on %s %s, fetching the latest version remaining attempts: %d", s.name, id, remainingAttempts)
+	}
+
+	return true
+}
+
+// ReadOnlyResourceService allows access to the operations of FORM3 resources that can only be fetched and listed,
+// like reference data.
+type ReadOnlyResourceService[T ResourceData] struct {
+	resources *ResourceService[T]
+}
+
+// NewReadOnlyResourceService creates a read only service for the resources available in a given path.
+func NewReadOnlyResourceService[T ResourceData](client *Client, path string, name string) *ReadOnlyResourceService[T] {
+	return &ReadOnlyResourceService[T]{resources: NewResourceService[T](client, path, name)}
+}
+
+// Path returns the path to the resource collection.
+func (s *ReadOnlyResourceService[T]) Path() string {
+	return s.resources.Path()
+}
+
+// Fetch allows one to fetch a resource.
+func (s *ReadOnlyResourceService[T]) Fetch(id string) (*Resource[T], *ResponseMeta, error) {
+	return s.resources.Fetch(id)
+}
+
+// FetchWithContext is like Fetch but the request is cancelled once the provided context is done.
+func (s *ReadOnlyResourceService[T]) FetchWithContext(ctx context.Context, id string) (*Resource[T], *ResponseMeta, error) {
+	return s.resources.FetchWithContext(ctx, id)
+}
+
+// List allows one to list a page of resources.
+func (s *ReadOnlyResourceService[T]) List(options ListOptions) (*ResourceList[T], *ResponseMeta, error) {
+	return s.resources.List(options)
+}
+
+// ListWithContext is like List but the request is cancelled once the provided context is done.
+func (s *ReadOnlyResourceService[T]) ListWithContext(ctx context.Context, options ListOptions) (*ResourceList[T], *ResponseMeta, error) {
+	return s.resources.ListWithContext(ctx, options)
+}
+
+// ImmutableResourceService allows access to the operations of FORM3 resources that can be created, fetched and listed,
+// but never updated or deleted, like payments.
+type ImmutableResourceService[T ResourceData] struct {
+	resources *ResourceService[T]
+}
+
+// NewImmutableResourceService creates a service for the immutable resources available in a given path.
+func NewImmutableResourceService[T ResourceData](client *Client, path string, name string) *ImmutableResourceService[T] {
+	return &ImmutableResourceService[T]{resources: NewResourceService[T](client, path, name)}
+}
+
+// Path returns the path to the resource collection.
+func (s *ImmutableResourceService[T]) Path() string {
+	return s.resources.Path()
+}
+
+// Create allows one to create a resource.
+func (s *ImmutableResourceService[T]) Create(resource *Resource[T]) (*Resource[T], *ResponseMeta, error) {
+	return s.resources.Create(resource)
+}
+
+// CreateWithContext is like Create but the request is cancelled once the provided context is done.
+func (s *ImmutableResourceService[T]) CreateWithContext(ctx context.Context, resource *Resource[T]) (*Resource[T], *ResponseMeta, error) {
+	return s.resources.CreateWithContext(ctx, resource)
+}
+
+// Fetch allows one to fetch a resource.
+func (s *ImmutableResourceService[T]) Fetch(id string) (*Resource[T], *ResponseMeta, error) {
+	return s.resources.Fetch(id)
+}
+
+// FetchWithContext is like Fetch but the request is cancelled once the provided context is done.
+func (s *ImmutableResourceService[T]) FetchWithContext(ctx context.Context, id string) (*Resource[T], *ResponseMeta, error) {
+	return s.resources.FetchWithContext(ctx, id)
+}
+
+// List allows one to list a page of resources.
+func (s *ImmutableResourceService[T]) List(options ListOptions) (*ResourceList[T], *ResponseMeta, error) {
+	return s.resources.List(options)
+}
+
+// ListWithContext is like List but the request is cancelled once the provided context is done.
+func (s *ImmutableResourceService[T]) ListWithContext(ctx context.Context, options ListOptions) (*ResourceList[T], *ResponseMeta, error) {
+	return s.resources.ListWithContext(ctx, options)
+}
+
+// listAll lists every page of resources, starting at the first page.
+//
+// The response details are the ones of the last page.
+func listAll[T any](ctx context.Context, list func(context.Context, ListOptions) (*ResourceList[T], *ResponseMeta, error), options ListOptions) ([]T, *ResponseMeta, error) {
+	resources := []T{}
+
+	for options.PageNumber = 0; ; options.PageNumber++ {
+		page, response, error := list(ctx, options)
+
+		if error != nil {
+			return nil, response, error
+		}
+
+		resources = append(resources, page.Data...)
+
+		if len(page.Data) == 0 || page.Links == nil || page.Links.Next == "" {
+			return resources, response, nil
+		}
+	}
+}
+
+// handleResourceResponse performs a request and unmarshals the response body into a JSON:API document.
+//
+// It is a function instead of a method since methods cannot have their own type parameters.
+func handleResourceResponse[D any, T ResourceData](ctx context.Context, s *ResourceService[T], httpMethod string, requestURL string, body []byte, successfulStatusCode int) (*D, *ResponseMeta, error) {
+	response, error := s.Client.PerformRequestWithContext(ctx, httpMethod, requestURL, body)
+
+	if error != nil {
+		return nil, nil, error
+	}
+
+	defer response.Body.Close()
+
+	body, error = s.ReadAll(response.Body)
+
+	if error != nil {
+		return nil, response, OperationError{Message: error.Error()}
+	}
+
+	if response.StatusCode != successfulStatusCode {
+		return nil, response, newResponseError(response, body)
+	}
+
+	document := new(D)
+	error = s.JsonUnmarshal(body, &document)
+
+	if error != nil {
+		return nil, response, OperationError{Message: error.Error()}
+	}
+
+	return document, response, nil
+}
@@ -0,0 +1,239 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/stretchr/testify/assert"
+)
+
+// widgetData is a resource used to test the generic resource service.
+type widgetData struct {
+	ID      string `json:"id"`
+	Version int64  `json:"version"`
+	Colour  string `json:"colour"`
+}
+
+func (d widgetData) ResourceID() string {
+	return d.ID
+}
+
+func (d widgetData) ResourceVersion() int64 {
+	return d.Version
+}
+
+// newResourceServer creates a server that replies with the given status code and body, recording the last request.
+func newResourceServer(t *testing.T, statusCode int, body string) (*httptest.Server, *http.Request, *[]byte) {
+	received := &http.Request{}
+	receivedBody := &[]byte{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*received = *r.Clone(context.Background())
+		*receivedBody, _ = io.ReadAll(r.Body)
+
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(body))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, received, receivedBody
+}
+
+func newWidgetService(t *testing.T, server *httptest.Server) *form3.ResourceService[widgetData] {
+	client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+
+	return form3.NewResourceService[widgetData](client, "/v1/widgets", "widget")
+}
+
+func TestResourceService(t *testing.T) {
+	t.Run("should create a resource in the resource path", func(t *testing.T) {
+		t.Parallel()
+
+		server, request, body := newResourceServer(t, 201, `{"data":{"id":"w1","version":0,"colour":"red"},"links":{"self":"/v1/widgets/w1"}}`)
+		service := newWidgetService(t, server)
+
+		widget, response, error := service.Create(&form3.Resource[widgetData]{Data: &widgetData{ID: "w1", Colour: "red"}})
+
+		assert.Nil(t, error)
+		assert.Equal(t, 201, response.StatusCode)
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "/v1/widgets", request.URL.Path)
+		assert.JSONEq(t, `{"data":{"id":"w1","version":0,"colour":"red"}}`, string(*body))
+		assert.Equal(t, &form3.Resource[widgetData]{
+			Data:  &widgetData{ID: "w1", Colour: "red"},
+			Links: &form3.Links{Self: "/v1/widgets/w1"},
+		}, widget)
+	})
+
+	t.Run("should list a page of resources using the pagination and filters", func(t *testing.T) {
+		t.Parallel()
+
+		server, request, _ := newResourceServer(t, 200, `{"data":[{"id":"w1","colour":"red"},{"id":"w2","colour":"red"}],"links":{"first":"/v1/widgets?page%5Bnumber%5D=first","next":"/v1/widgets?page%5Bnumber%5D=2"}}`)
+		service := newWidgetService(t, server)
+
+		widgets, _, error := service.List(form3.ListOptions{PageNumber: 1, PageSize: 2, Filter: map[string]string{"colour": "red"}})
+
+		assert.Nil(t, error)
+		assert.Equal(t, "/v1/widgets", request.URL.Path)
+		assert.Equal(t, url.Values{"page[number]": {"1"}, "page[size]": {"2"}, "filter[colour]": {"red"}}, request.URL.Query())
+		assert.Equal(t, []widgetData{{ID: "w1", Colour: "red"}, {ID: "w2", Colour: "red"}}, widgets.Data)
+		assert.Equal(t, "/v1/widgets?page%5Bnumber%5D=2", widgets.Links.Next)
+	})
+
+	t.Run("should list resources without a query when no options are provided", func(t *testing.T) {
+		t.Parallel()
+
+		server, request, _ := newResourceServer(t, 200, `{"data":[]}`)
+		service := newWidgetService(t, server)
+
+		widgets, _, error := service.List(form3.ListOptions{})
+
+		assert.Nil(t, error)
+		assert.Equal(t, "", request.URL.RawQuery)
+		assert.Empty(t, widgets.Data)
+		assert.Nil(t, widgets.Links)
+	})
+
+	t.Run("should update a resource using its ID", func(t *testing.T) {
+		t.Parallel()
+
+		server, request, _ := newResourceServer(t, 200, `{"data":{"id":"w1","version":1,"colour":"blue"}}`)
+		service := newWidgetService(t, server)
+
+		widget, _, error := service.Update(&form3.Resource[widgetData]{Data: &widgetData{ID: "w1", Colour: "blue"}})
+
+		assert.Nil(t, error)
+		assert.Equal(t, http.MethodPatch, request.Method)
+		assert.Equal(t, "/v1/widgets/w1", request.URL.Path)
+		assert.Equal(t, int64(1), widget.Data.Version)
+	})
+
+	t.Run("should not update a resource without data", func(t *testing.T) {
+		t.Parallel()
+
+		server, _, _ := newResourceServer(t, 200, ``)
+		service := newWidgetService(t, server)
+
+		widget, response, error := service.Update(&form3.Resource[widgetData]{})
+
+		assert.Nil(t, widget)
+		assert.Nil(t, response)
+		assert.Equal(t, form3.OperationError{Message: "widget data is required"}, error)
+	})
+
+	t.Run("should delete a resource using its version", func(t *testing.T) {
+		t.Parallel()
+
+		server, request, _ := newResourceServer(t, 204, ``)
+		service := newWidgetService(t, server)
+
+		response, error := service.Delete("w1", 3)
+
+		assert.Nil(t, error)
+		assert.Equal(t, 204, response.StatusCode)
+		assert.Equal(t, "/v1/widgets/w1", request.URL.Path)
+		assert.Equal(t, "version=3", request.URL.RawQuery)
+	})
+
+	t.Run("should return the error used to stop changing a resource", func(t *testing.T) {
+		t.Parallel()
+
+		server, _, _ := newResourceServer(t, 200, `{"data":{"id":"w1","version":1,"colour":"red"}}`)
+		service := newWidgetService(t, server)
+		mutateError := errors.New("colour cannot be changed")
+
+		widget, response, error := service.UpdateWithRetry("w1", func(widget *form3.Resource[widgetData]) error {
+			return mutateError
+		})
+
+		assert.Nil(t, widget)
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, form3.OperationError{Message: "colour cannot be changed", Cause: mutateError}, error)
+		assert.ErrorIs(t, error, mutateError)
+	})
+
+	t.Run("should not change a resource once the context is done", func(t *testing.T) {
+		t.Parallel()
+
+		server, _, _ := newResourceServer(t, 200, `{"data":{"id":"w1","version":1,"colour":"red"}}`)
+		service := newWidgetService(t, server)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		mutated := false
+
+		widget, _, error := service.UpdateWithRetryWithContext(ctx, "w1", func(widget *form3.Resource[widgetData]) error {
+			mutated = true
+
+			return nil
+		})
+
+		assert.Nil(t, widget)
+		assert.ErrorContains(t, error, "context canceled")
+		assert.False(t, mutated)
+	})
+
+	t.Run("should return an error when the server does not reply successfully", func(t *testing.T) {
+		t.Parallel()
+
+		server, _, _ := newResourceServer(t, 404, `{"error_message":"record w1 does not exist"}`)
+		service := newWidgetService(t, server)
+
+		widget, response, error := service.Fetch("w1")
+
+		assert.Nil(t, widget)
+		assert.Equal(t, 404, response.StatusCode)
+		assert.Equal(t, form3.OperationError{Message: "404 Not Found", Body: []byte(`{"error_message":"record w1 does not exist"}`)}, error)
+	})
+
+	t.Run("should return the path of the resource collection", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := form3.New()
+
+		assert.Equal(t, "/v1/organisation/accounts", client.Accounts.Path())
+	})
+}
+
+func TestResourceFields(t *testing.T) {
+	t.Run("should encode the fields alongside the attributes", func(t *testing.T) {
+		t.Parallel()
+
+		payment := form3.PaymentData{
+			OrganisationResourceFields: form3.OrganisationResourceFields{ID: "4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43", OrganisationID: parentOrganisationID, Type: "payments", Version: 1},
+			Attributes:                 &form3.PaymentAttributes{Amount: "100.21"},
+		}
+
+		encoded, error := json.Marshal(payment)
+
+		assert.Nil(t, error)
+		assert.Equal(t, `{"attributes":{"amount":"100.21"},"id":"4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43","organisation_id":"`+parentOrganisationID+`","type":"payments","version":1}`, string(encoded))
+
+		decoded := form3.PaymentData{}
+		error = json.Unmarshal(encoded, &decoded)
+
+		assert.Nil(t, error)
+		assert.Equal(t, payment, decoded)
+		assert.Equal(t, payment.ID, decoded.ResourceID())
+		assert.Equal(t, int64(1), decoded.ResourceVersion())
+		assert.Equal(t, parentOrganisationID, decoded.ResourceOrganisationID())
+	})
+
+	t.Run("should not encode an organisation for resources that do not belong to one", func(t *testing.T) {
+		t.Parallel()
+
+		encoded, error := json.Marshal(form3.BicData{ResourceFields: form3.ResourceFields{ID: "b1", Type: "bics"}})
+
+		assert.Nil(t, error)
+		assert.Equal(t, `{"id":"b1","type":"bics"}`, string(encoded))
+	})
+}
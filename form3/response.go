@@ -0,0 +1,47 @@
+package form3
+
+import (
+	"net/http"
+	"time"
+)
+
+// RequestIDHeader contains the ID used to identify a request, sent by the client and returned by the server.
+const RequestIDHeader = "X-Request-ID"
+
+// ResponseMeta contains the last http response of an operation and details about how it was obtained.
+//
+// The http response body is already closed once an operation returns.
+type ResponseMeta struct {
+	*http.Response                     // Last http response obtained, nil if no http response was obtained.
+	ClientRequestID    string          // ID of the request sent by the client in all attempts.
+	RequestID          string          // ID of the request returned by the server, if any.
+	RateLimitRemaining int             // How many requests can still be performed until the rate limit resets, -1 if unknown.
+	RateLimitReset     time.Time       // When the rate limit resets, zero if unknown.
+	Attempts           int             // How many http requests were performed.
+	AttemptDurations   []time.Duration // How much time each http request took, in the order they were performed.
+	Latency            time.Duration   // How much time the whole operation took, including the time between attempts.
+	Date               time.Time       // Time when the server generated the response, zero if unknown.
+}
+
+// newResponseMeta creates the details of an operation that did not perform any request yet.
+func newResponseMeta(clientRequestID string) *ResponseMeta {
+	return &ResponseMeta{ClientRequestID: clientRequestID, RateLimitRemaining: -1}
+}
+
+// setResponse stores the last http response and its details.
+func (m *ResponseMeta) setResponse(response *http.Response, now time.Time) {
+	m.Response = response
+	m.RequestID = response.Header.Get(RequestIDHeader)
+
+	if remaining, ok := headerNumber(response.Header, RateLimitRemainingHeader); ok {
+		m.RateLimitRemaining = int(remaining)
+	}
+
+	if reset, ok := headerNumber(response.Header, RateLimitResetHeader); ok {
+		m.RateLimitReset = now.Add(time.Duration(reset * float64(time.Second)))
+	}
+
+	if date, error := http.ParseTime(response.Header.Get("Date")); error == nil {
+		m.Date = date
+	}
+}
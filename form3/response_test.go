@@ -0,0 +1,85 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseMeta(t *testing.T) {
+	t.Run("should return the details of all attempts made when the operation is successful", func(t *testing.T) {
+		defer gock.Off()
+		now := time.Date(2023, 5, 1, 22, 52, 47, 0, time.UTC)
+		client, _ := form3.New(
+			form3.WithHttpTimeUntilNextAttempt(time.Second),
+			form3.WithClock(form3test.NewFakeClock(now).WithAutoAdvance()),
+		)
+		accountUuid := "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+
+		for i := 0; i <= 1; i++ {
+			gock.New("http://accountapi:8080").
+				Get("/v1/organisation/accounts/" + accountUuid).
+				Reply(503)
+		}
+
+		gock.New("http://accountapi:8080").
+			Get("/v1/organisation/accounts/"+accountUuid).
+			Reply(200).
+			SetHeader(form3.RequestIDHeader, "a3c5bd3b-ff8b-4b5c-9e5e-bfd0e2b7a0c4").
+			SetHeader(form3.RateLimitRemainingHeader, "41").
+			SetHeader(form3.RateLimitResetHeader, "30").
+			SetHeader("Date", "Mon, 01 May 2023 22:52:47 GMT").
+			BodyString("{\"data\": {\"id\": \"" + accountUuid + "\"}}")
+
+		account, response, error := client.Accounts.Fetch(accountUuid)
+
+		assert.Nil(t, error)
+		assert.Equal(t, accountUuid, account.Data.ID)
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, "a3c5bd3b-ff8b-4b5c-9e5e-bfd0e2b7a0c4", response.RequestID)
+		assert.Equal(t, 41, response.RateLimitRemaining)
+		assert.Equal(t, 3, response.Attempts)
+		assert.Equal(t, []time.Duration{0, 0, 0}, response.AttemptDurations)
+		assert.Equal(t, now, response.Date)
+		assert.Equal(t, now.Add(response.Latency+30*time.Second), response.RateLimitReset)
+		assert.Greater(t, response.Latency, 6*time.Second)
+	})
+
+	t.Run("should return unknown details when the server does not provide them", func(t *testing.T) {
+		defer gock.Off()
+		client, _ := form3.New()
+
+		gock.New("http://accountapi:8080").
+			Delete("/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc").
+			Reply(404)
+
+		response, _ := client.Accounts.Delete("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", 0)
+
+		assert.Equal(t, 404, response.StatusCode)
+		assert.Equal(t, "", response.RequestID)
+		assert.Equal(t, -1, response.RateLimitRemaining)
+		assert.True(t, response.RateLimitReset.IsZero())
+		assert.True(t, response.Date.IsZero())
+		assert.Equal(t, 1, response.Attempts)
+	})
+
+	t.Run("should return the details of all attempts made in the error when no response was obtained", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := form3.New(form3.WithBaseUrl(&url.URL{Scheme: "asdf", Host: "asdf"}))
+
+		account, response, error := client.Accounts.Create(&form3.Account{})
+
+		assert.Nil(t, account)
+		assert.Nil(t, response)
+		assert.Equal(t, 1, error.(form3.OperationError).Meta.Attempts)
+		assert.Nil(t, error.(form3.OperationError).Meta.Response)
+	})
+}
@@ -0,0 +1,193 @@
+package form3
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	usersUri string = "/v1/security/users" // usersUri contains the path to the user resources.
+	rolesUri string = "/v1/security/roles" // rolesUri contains the path to the role resources.
+)
+
+// aceType is the JSON:API type of an access control entry.
+const aceType string = "aces"
+
+// acePageSize is the number of access control entries listed per page when granting actions to a role.
+const acePageSize = 100
+
+// AceAction is an action a role is allowed to perform on a record type.
+type AceAction string
+
+const (
+	AceActionCreate  AceAction = "CREATE"  // AceActionCreate allows records to be created.
+	AceActionRead    AceAction = "READ"    // AceActionRead allows records to be fetched and listed.
+	AceActionEdit    AceAction = "EDIT"    // AceActionEdit allows records to be updated.
+	AceActionDelete  AceAction = "DELETE"  // AceActionDelete allows records to be deleted.
+	AceActionApprove AceAction = "APPROVE" // AceActionApprove allows changes to records to be approved.
+	AceActionReject  AceAction = "REJECT"  // AceActionReject allows changes to records to be rejected.
+)
+
+var (
+	readActions  = []AceAction{AceActionRead}                                                  // readActions are granted to read records.
+	writeActions = []AceAction{AceActionRead, AceActionCreate, AceActionEdit, AceActionDelete} // writeActions are granted to read and change records.
+)
+
+// UserService allows access to operations related to API users.
+//
+// Users can be created, fetched, listed, updated and deleted. What a user is allowed to do depends on its roles.
+//
+// More details available in: https://www.api-docs.form3.tech/api/platform/security/users
+type UserService struct {
+	*ResourceService[UserData]
+}
+
+// RoleService allows access to operations related to roles and their access control list.
+//
+// Roles can be created, fetched, listed, updated and deleted. Every role has access control entries,
+// each allowing an action on a record type.
+//
+// More details available in: https://www.api-docs.form3.tech/api/platform/security/roles
+type RoleService struct {
+	*ResourceService[RoleData]
+}
+
+// Represents a FORM3 user.
+type User = Resource[UserData]
+
+// Represents a FORM3 user data.
+type UserData struct {
+	Attributes *UserAttributes `json:"attributes,omitempty"`
+	OrganisationResourceFields
+}
+
+// Represents a FORM3 user attributes.
+type UserAttributes struct {
+	Email    string   `json:"email,omitempty"`
+	RoleIDs  []string `json:"role_ids,omitempty"`
+	Username string   `json:"username,omitempty"`
+}
+
+// Represents a FORM3 role.
+type Role = Resource[RoleData]
+
+// Represents a FORM3 role data.
+type RoleData struct {
+	Attributes *RoleAttributes `json:"attributes,omitempty"`
+	OrganisationResourceFields
+}
+
+// Represents a FORM3 role attributes.
+type RoleAttributes struct {
+	Name         string `json:"name,omitempty"`
+	ParentRoleID string `json:"parent_role_id,omitempty"`
+}
+
+// Represents a FORM3 access control entry, which allows a role to perform an action on a record type.
+type Ace = Resource[AceData]
+
+// Represents a FORM3 access control entry data.
+type AceData struct {
+	Attributes *AceAttributes `json:"attributes,omitempty"`
+	OrganisationResourceFields
+}
+
+// Represents a FORM3 access control entry attributes.
+type AceAttributes struct {
+	Action     AceAction  `json:"action,omitempty"`
+	RecordType RecordType `json:"record_type,omitempty"`
+	RoleID     string     `json:"role_id,omitempty"`
+}
+
+// Aces allows access to the access control entries of a role.
+func (s *RoleService) Aces(roleId string) *ResourceService[AceData] {
+	return newSubResourceService[AceData](s.ResourceService, fmt.Sprintf("%s/aces", roleId), "access control entry")
+}
+
+// Grant allows a role to perform actions on a record type.
+//
+// Actions the role is already allowed to perform are not granted again.
+// The access control entries of every requested action are returned.
+func (s *RoleService) Grant(role *Role, recordType RecordType, actions ...AceAction) ([]AceData, *ResponseMeta, error) {
+	return s.GrantWithContext(context.Background(), role, recordType, actions...)
+}
+
+// GrantWithContext is like Grant but the requests are cancelled once the provided context is done.
+func (s *RoleService) GrantWithContext(ctx context.Context, role *Role, recordType RecordType, actions ...AceAction) ([]AceData, *ResponseMeta, error) {
+	if role == nil || role.Data == nil {
+		return nil, nil, OperationError{Message: "role data is required"}
+	}
+
+	// Every page is listed, so that actions granted beyond the first page are not granted again
+	aces := s.Aces(role.Data.ID)
+	existing, response, error := listAll(ctx, aces.ListWithContext, ListOptions{PageSize: acePageSize, Filter: map[string]string{"record_type": string(recordType)}})
+
+	if error != nil {
+		return nil, response, error
+	}
+
+	granted := map[AceAction]AceData{}
+
+	for _, ace := range existing {
+		if ace.Attributes != nil {
+			granted[ace.Attributes.Action] = ace
+		}
+	}
+
+	result := make([]AceData, 0, len(actions))
+
+	for _, action := range actions {
+		if ace, ok := granted[action]; ok {
+			result = append(result, ace)
+
+			continue
+		}
+
+		id, error := newUuid()
+
+		if error != nil {
+			return nil, response, OperationError{Message: error.Error()}
+		}
+
+		ace, aceResponse, error := aces.CreateWithContext(ctx, &Ace{
+			Data: &AceData{
+				OrganisationResourceFields: OrganisationResourceFields{ID: id, OrganisationID: role.Data.OrganisationID, Type: aceType},
+				Attributes:                 &AceAttributes{Action: action, RecordType: recordType, RoleID: role.Data.ID},
+			},
+		})
+
+		if error != nil {
+			return nil, aceResponse, error
+		}
+
+		if ace.Data == nil {
+			return nil, aceResponse, OperationError{Message: "access control entry data is missing"}
+		}
+
+		response = aceResponse
+		granted[action] = *ace.Data
+		result = append(result, *ace.Data)
+	}
+
+	return result, response, nil
+}
+
+// GrantAccountsRead allows a role to fetch and list accounts.
+func (s *RoleService) GrantAccountsRead(role *Role) ([]AceData, *ResponseMeta, error) {
+	return s.GrantAccountsReadWithContext(context.Background(), role)
+}
+
+// GrantAccountsReadWithContext is like GrantAccountsRead but the requests are cancelled once the provided context is done.
+func (s *RoleService) GrantAccountsReadWithContext(ctx context.Context, role *Role) ([]AceData, *ResponseMeta, error) {
+	return s.GrantWithContext(ctx, role, RecordTypeAccounts, readActions...)
+}
+
+// GrantAccountsWrite allows a role to fetch, list, create, update and delete accounts.
+func (s *RoleService) GrantAccountsWrite(role *Role) ([]AceData, *ResponseMeta, error) {
+	return s.GrantAccountsWriteWithContext(context.Background(), role)
+}
+
+// GrantAccountsWriteWithContext is like GrantAccountsWrite but the requests are cancelled once the provided context is done.
+func (s *RoleService) GrantAccountsWriteWithContext(ctx context.Context, role *Role) ([]AceData, *ResponseMeta, error) {
+	return s.GrantWithContext(ctx, role, RecordTypeAccounts, writeActions...)
+}
@@ -0,0 +1,134 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
+	"github.com/stretchr/testify/assert"
+)
+
+func aceActions(aces []form3.AceData) []form3.AceAction {
+	actions := []form3.AceAction{}
+
+	for _, ace := range aces {
+		actions = append(actions, ace.Attributes.Action)
+	}
+
+	return actions
+}
+
+func TestSecurity(t *testing.T) {
+	t.Run("should set up a user with a role that can read and write accounts", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()))
+		scoped := client.ForOrganisation(parentOrganisationID)
+
+		role, _, error := scoped.Roles.Create(&form3.Role{Data: &form3.RoleData{OrganisationResourceFields: form3.OrganisationResourceFields{ID: "6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d", Type: "roles"}, Attributes: &form3.RoleAttributes{Name: "accounts-writer"}}})
+
+		assert.Nil(t, error)
+		assert.Equal(t, parentOrganisationID, role.Data.OrganisationID)
+
+		read, _, error := client.Roles.GrantAccountsRead(role)
+
+		assert.Nil(t, error)
+		assert.Equal(t, []form3.AceAction{form3.AceActionRead}, aceActions(read))
+
+		write, response, error := client.Roles.GrantAccountsWrite(role)
+
+		assert.Nil(t, error)
+		assert.Equal(t, 201, response.StatusCode)
+		assert.Equal(t, []form3.AceAction{form3.AceActionRead, form3.AceActionCreate, form3.AceActionEdit, form3.AceActionDelete}, aceActions(write))
+		assert.Equal(t, read[0], write[0])
+		assert.Equal(t, form3.AceAttributes{Action: form3.AceActionEdit, RecordType: form3.RecordTypeAccounts, RoleID: role.Data.ID}, *write[2].Attributes)
+		assert.Equal(t, parentOrganisationID, write[2].OrganisationID)
+
+		aces, _, error := client.Roles.Aces(role.Data.ID).List(form3.ListOptions{})
+
+		assert.Nil(t, error)
+		assert.Len(t, aces.Data, 4)
+
+		user, _, error := scoped.Users.Create(&form3.User{Data: &form3.UserData{
+			OrganisationResourceFields: form3.OrganisationResourceFields{ID: "7b8c9d0e-1f2a-4b3c-9d4e-5f6a7b8c9d0e", Type: "users"},
+			Attributes:                 &form3.UserAttributes{Username: "infra-bot", Email: "infra@example.com", RoleIDs: []string{role.Data.ID}},
+		}})
+
+		assert.Nil(t, error)
+		assert.Equal(t, []string{role.Data.ID}, user.Data.Attributes.RoleIDs)
+
+		_, exists := server.Resource("/v1/security/users/" + user.Data.ID)
+
+		assert.True(t, exists)
+	})
+
+	t.Run("should not grant again actions already granted", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()))
+		role := &form3.Role{Data: &form3.RoleData{OrganisationResourceFields: form3.OrganisationResourceFields{ID: "6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d", OrganisationID: parentOrganisationID}}}
+
+		first, _, error := client.Roles.Grant(role, form3.RecordTypePayments, form3.AceActionApprove)
+
+		assert.Nil(t, error)
+
+		second, response, error := client.Roles.Grant(role, form3.RecordTypePayments, form3.AceActionApprove)
+
+		assert.Nil(t, error)
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("should not grant again actions granted beyond the first page", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()))
+		role := &form3.Role{Data: &form3.RoleData{OrganisationResourceFields: form3.OrganisationResourceFields{ID: "6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d", OrganisationID: parentOrganisationID}}}
+
+		// Fills the first page of access control entries
+		for index := 0; index < 100; index++ {
+			_, _, error := client.Roles.Aces(role.Data.ID).Create(&form3.Ace{Data: &form3.AceData{
+				OrganisationResourceFields: form3.OrganisationResourceFields{ID: fmt.Sprintf("00000000-0000-4000-8000-%012d", index), Type: "aces"},
+				Attributes:                 &form3.AceAttributes{Action: form3.AceActionRead, RecordType: form3.RecordTypePayments, RoleID: role.Data.ID},
+			}})
+
+			assert.Nil(t, error)
+		}
+
+		first, _, error := client.Roles.Grant(role, form3.RecordTypePayments, form3.AceActionApprove)
+
+		assert.Nil(t, error)
+
+		second, _, error := client.Roles.Grant(role, form3.RecordTypePayments, form3.AceActionApprove)
+
+		assert.Nil(t, error)
+		assert.Equal(t, first, second)
+
+		aces, _, error := client.Roles.Aces(role.Data.ID).List(form3.ListOptions{PageNumber: 1})
+
+		assert.Nil(t, error)
+		assert.Len(t, aces.Data, 1)
+		assert.Equal(t, first[0], aces.Data[0])
+	})
+
+	t.Run("should not grant actions to a role without data", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := form3.New()
+
+		aces, response, error := client.Roles.GrantAccountsRead(&form3.Role{})
+
+		assert.Nil(t, aces)
+		assert.Nil(t, response)
+		assert.Equal(t, form3.OperationError{Message: "role data is required"}, error)
+	})
+}
@@ -0,0 +1,122 @@
+package form3
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SignatureAlgorithmRsa   = "rsa-sha256"   // SignatureAlgorithmRsa is used by signers with a RSA private key.
+	SignatureAlgorithmEcdsa = "ecdsa-sha256" // SignatureAlgorithmEcdsa is used by signers with an ECDSA private key.
+)
+
+// Signer signs http requests with a private key, whose public key was uploaded to FORM3.
+//
+// Requests are signed following the HTTP Signatures draft: the request target, host and date are always signed,
+// as well as the digest, content type and content length of requests with a body.
+// The signature is sent in the Authorization header.
+//
+// More details available in: https://www.api-docs.form3.tech/api/tutorials/getting-started/create-a-signed-request
+type Signer struct {
+	keyId      string
+	privateKey crypto.Signer
+	algorithm  string
+}
+
+// NewSigner creates a signer using the ID of an uploaded public key and the matching private key.
+//
+// An error is returned if there is no key ID or if the private key is not a RSA or ECDSA key.
+func NewSigner(keyId string, privateKey crypto.Signer) (*Signer, error) {
+	if keyId == "" {
+		return nil, OperationError{Message: "key id is required"}
+	}
+
+	switch privateKey.(type) {
+	case *rsa.PrivateKey:
+		return &Signer{keyId: keyId, privateKey: privateKey, algorithm: SignatureAlgorithmRsa}, nil
+	case *ecdsa.PrivateKey:
+		return &Signer{keyId: keyId, privateKey: privateKey, algorithm: SignatureAlgorithmEcdsa}, nil
+	default:
+		return nil, OperationError{Message: fmt.Sprintf("private key of type %T is not supported", privateKey)}
+	}
+}
+
+// KeyID returns the ID of the public key used to verify the signatures.
+func (s *Signer) KeyID() string {
+	return s.keyId
+}
+
+// Sign sets the date, the digest and the authorization headers of a request.
+//
+// The body must be the one sent by the request, if any.
+func (s *Signer) Sign(request *http.Request, body []byte, now time.Time) error {
+	request.Header.Set("Date", now.UTC().Format(http.TimeFormat))
+	headers := []string{"(request-target)", "host", "date"}
+
+	if body != nil {
+		digest := sha256.Sum256(body)
+		request.Header.Set("Digest", fmt.Sprintf("SHA-256=%s", base64.StdEncoding.EncodeToString(digest[:])))
+		request.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		headers = append(headers, "digest", "content-type", "content-length")
+	}
+
+	hashed := sha256.Sum256([]byte(SigningString(request, headers)))
+	signature, error := s.privateKey.Sign(rand.Reader, hashed[:], crypto.SHA256)
+
+	if error != nil {
+		return OperationError{Message: fmt.Sprintf("request could not be signed: %s", error.Error())}
+	}
+
+	request.Header.Set("Authorization", fmt.Sprintf(`Signature keyId="%s",algorithm="%s",headers="%s",signature="%s"`,
+		s.keyId, s.algorithm, strings.Join(headers, " "), base64.StdEncoding.EncodeToString(signature)))
+
+	return nil
+}
+
+// SigningString returns the string signed for a request, made of the given headers in order.
+//
+// Useful to verify the signature of a request, for example in tests.
+func SigningString(request *http.Request, headers []string) string {
+	lines := make([]string, 0, len(headers))
+
+	for _, header := range headers {
+		switch header {
+		case "(request-target)":
+			lines = append(lines, fmt.Sprintf("(request-target): %s %s", strings.ToLower(request.Method), request.URL.RequestURI()))
+		case "host":
+			host := request.Host
+
+			if host == "" {
+				host = request.URL.Host
+			}
+
+			lines = append(lines, fmt.Sprintf("host: %s", host))
+		default:
+			lines = append(lines, fmt.Sprintf("%s: %s", header, request.Header.Get(header)))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// WithSigner sets the signer used to sign every http request attempt.
+func WithSigner(signer *Signer) Option {
+	return func(c *Client) error {
+		if signer == nil {
+			return fmt.Errorf("signer is required")
+		}
+
+		c.signer = signer
+
+		return nil
+	}
+}
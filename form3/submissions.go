@@ -0,0 +1,91 @@
+package form3
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// finalSubmissionStatuses contains the statuses after which a submission does not change anymore.
+var finalSubmissionStatuses = []string{
+	PaymentSubmissionDeliveryConfirmed,
+	PaymentSubmissionDeliveryFailed,
+	PaymentSubmissionValidationRejected,
+}
+
+// SubmittableData is implemented by the data of every FORM3 resource that is sent to the scheme using submissions.
+type SubmittableData interface {
+	ResourceData
+	ResourceOrganisationID() string // Returns the ID of the organisation that owns the resource.
+}
+
+// SubmittableService allows access to the operations of resources that are sent to the scheme using submissions.
+//
+// Payments, returns, reversals, recalls and recall decisions are all submitted the same way.
+// They can only be created, fetched and listed, since they cannot be changed once created.
+type SubmittableService[T SubmittableData] struct {
+	*ImmutableResourceService[T]
+	submissionType string // JSON:API type of the submissions.
+}
+
+func newSubmittableService[T SubmittableData](service *ResourceService[T], submissionType string) *SubmittableService[T] {
+	return &SubmittableService[T]{ImmutableResourceService: &ImmutableResourceService[T]{resources: service}, submissionType: submissionType}
+}
+
+// Submissions allows access to the submissions of a resource.
+//
+// Submissions can only be created, fetched and listed.
+func (s *SubmittableService[T]) Submissions(id string) *ImmutableResourceService[PaymentSubmissionData] {
+	submissions := newSubResourceService[PaymentSubmissionData](s.resources, fmt.Sprintf("%s/submissions", id), fmt.Sprintf("%s submission", s.resources.name))
+
+	return &ImmutableResourceService[PaymentSubmissionData]{resources: submissions}
+}
+
+// Submit allows one to submit a resource, sending it to the scheme.
+//
+// The progress of the resource can be followed by fetching the returned submission.
+//
+// More details available in: https://www.api-docs.form3.tech/api/schemes/fps-direct/payments/payment-submissions/create-a-payment-submission
+func (s *SubmittableService[T]) Submit(resource *Resource[T]) (*PaymentSubmission, *ResponseMeta, error) {
+	return s.SubmitWithContext(context.Background(), resource)
+}
+
+// SubmitWithContext is like Submit but the request is cancelled once the provided context is done.
+func (s *SubmittableService[T]) SubmitWithContext(ctx context.Context, resource *Resource[T]) (*PaymentSubmission, *ResponseMeta, error) {
+	if resource == nil || resource.Data == nil {
+		return nil, nil, OperationError{Message: fmt.Sprintf("%s data is required", s.resources.name)}
+	}
+
+	id, error := newUuid()
+
+	if error != nil {
+		return nil, nil, OperationError{Message: error.Error()}
+	}
+
+	submission := &PaymentSubmission{
+		Data: &PaymentSubmissionData{
+			OrganisationResourceFields: OrganisationResourceFields{ID: id, OrganisationID: (*resource.Data).ResourceOrganisationID(), Type: s.submissionType},
+		},
+	}
+
+	return s.Submissions((*resource.Data).ResourceID()).CreateWithContext(ctx, submission)
+}
+
+// WaitForSubmission fetches a submission every interval until it reaches a final status or the context is done.
+//
+// A submission is final once its delivery was confirmed, failed or it did not pass validation.
+// The last submission fetched is returned together with the error if the context is done.
+func (s *SubmittableService[T]) WaitForSubmission(ctx context.Context, id string, submissionId string, interval time.Duration) (*PaymentSubmission, *ResponseMeta, error) {
+	options := WaitOptions{Interval: interval, BackoffMultiplier: 1, MaxInterval: interval, FinalStatuses: finalSubmissionStatuses}
+
+	return WaitForStatus(ctx, s.Submissions(id).resources, submissionId, submissionStatus, finalSubmissionStatuses, options)
+}
+
+// submissionStatus returns the status of a submission, empty if unknown.
+func submissionStatus(data *PaymentSubmissionData) string {
+	if data.Attributes == nil {
+		return ""
+	}
+
+	return data.Attributes.Status
+}
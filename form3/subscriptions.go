@@ -0,0 +1,138 @@
+package form3
+
+import (
+	"context"
+	"net/url"
+)
+
+// subscriptionsUri contains the path to the subscription resources.
+const subscriptionsUri string = "/v1/notification/subscriptions"
+
+// subscriptionType is the JSON:API type of a subscription.
+const subscriptionType string = "subscriptions"
+
+// CallbackTransport is how the events of a subscription are delivered.
+type CallbackTransport string
+
+const (
+	CallbackTransportHttp  CallbackTransport = "http"  // CallbackTransportHttp delivers events with a POST request to the callback URI.
+	CallbackTransportQueue CallbackTransport = "queue" // CallbackTransportQueue delivers events to the queue of the callback URI.
+)
+
+// RecordType is the type of the resources whose events are delivered by a subscription.
+type RecordType string
+
+const (
+	RecordTypeAccounts           RecordType = "accounts"            // RecordTypeAccounts is used to subscribe to account events.
+	RecordTypePayments           RecordType = "payments"            // RecordTypePayments is used to subscribe to payment events.
+	RecordTypePaymentSubmissions RecordType = "payment_submissions" // RecordTypePaymentSubmissions is used to subscribe to payment submission events.
+	RecordTypeMandates           RecordType = "mandates"            // RecordTypeMandates is used to subscribe to mandate events.
+	RecordTypeDirectDebits       RecordType = "directdebits"        // RecordTypeDirectDebits is used to subscribe to direct debit events.
+)
+
+// EventType is the change made to a resource that is delivered by a subscription.
+type EventType string
+
+const (
+	EventTypeCreated EventType = "created" // EventTypeCreated is used to subscribe to resources being created.
+	EventTypeUpdated EventType = "updated" // EventTypeUpdated is used to subscribe to resources being updated.
+	EventTypeDeleted EventType = "deleted" // EventTypeDeleted is used to subscribe to resources being deleted.
+)
+
+// SubscriptionService allows access to operations related to subscriptions.
+//
+// A subscription makes the server deliver an event every time a resource of a given record type changes.
+// Subscriptions can be created, fetched, listed, updated and deleted.
+//
+// More details available in: https://www.api-docs.form3.tech/api/platform/notifications/subscriptions
+type SubscriptionService struct {
+	*ResourceService[SubscriptionData]
+}
+
+// SubscriptionRequest contains the details of the events a subscription delivers.
+type SubscriptionRequest struct {
+	OrganisationID    string            // ID of the organisation whose events are delivered.
+	CallbackURI       string            // Where events are delivered, a URL or a queue depending on the transport.
+	CallbackTransport CallbackTransport // CallbackTransportHttp or CallbackTransportQueue.
+	RecordType        RecordType        // Type of the resources whose events are delivered.
+	EventType         EventType         // Change made to the resources that is delivered.
+}
+
+// Represents a FORM3 subscription.
+//
+// More details available in: https://www.api-docs.form3.tech/api/platform/notifications/subscriptions
+type Subscription = Resource[SubscriptionData]
+
+// Represents a FORM3 subscription data.
+type SubscriptionData struct {
+	Attributes *SubscriptionAttributes `json:"attributes,omitempty"`
+	OrganisationResourceFields
+}
+
+// Represents a FORM3 subscription attributes.
+type SubscriptionAttributes struct {
+	CallbackTransport CallbackTransport `json:"callback_transport,omitempty"`
+	CallbackURI       string            `json:"callback_uri,omitempty"`
+	Deactivated       bool              `json:"deactivated"` // Always sent, so that an update can activate the subscription again.
+	EventType         EventType         `json:"event_type,omitempty"`
+	RecordType        RecordType        `json:"record_type,omitempty"`
+	UserID            string            `json:"user_id,omitempty"`
+}
+
+// Subscribe allows one to create a subscription to the events of a record type.
+//
+// A random UUID is used as the subscription ID.
+func (s *SubscriptionService) Subscribe(request SubscriptionRequest) (*Subscription, *ResponseMeta, error) {
+	return s.SubscribeWithContext(context.Background(), request)
+}
+
+// SubscribeWithContext is like Subscribe but the request is cancelled once the provided context is done.
+func (s *SubscriptionService) SubscribeWithContext(ctx context.Context, request SubscriptionRequest) (*Subscription, *ResponseMeta, error) {
+	error := validateSubscriptionRequest(request)
+
+	if error != nil {
+		return nil, nil, error
+	}
+
+	id, error := newUuid()
+
+	if error != nil {
+		return nil, nil, OperationError{Message: error.Error()}
+	}
+
+	return s.CreateWithContext(ctx, &Subscription{
+		Data: &SubscriptionData{
+			OrganisationResourceFields: OrganisationResourceFields{ID: id, OrganisationID: request.OrganisationID, Type: subscriptionType},
+			Attributes: &SubscriptionAttributes{
+				CallbackTransport: request.CallbackTransport,
+				CallbackURI:       request.CallbackURI,
+				EventType:         request.EventType,
+				RecordType:        request.RecordType,
+			},
+		},
+	})
+}
+
+// validateSubscriptionRequest returns a ValidationError if a subscription request is missing details or cannot be delivered.
+func validateSubscriptionRequest(request SubscriptionRequest) error {
+	switch {
+	case request.RecordType == "":
+		return ValidationError{Field: "record_type", Message: "is required"}
+	case request.EventType == "":
+		return ValidationError{Field: "event_type", Message: "is required"}
+	case request.CallbackURI == "":
+		return ValidationError{Field: "callback_uri", Message: "is required"}
+	case request.CallbackTransport != CallbackTransportHttp && request.CallbackTransport != CallbackTransportQueue:
+		return ValidationError{Field: "callback_transport", Message: "must be http or queue"}
+	}
+
+	if request.CallbackTransport == CallbackTransportHttp {
+		callbackUrl, error := url.Parse(request.CallbackURI)
+
+		if error != nil || (callbackUrl.Scheme != "http" && callbackUrl.Scheme != "https") || callbackUrl.Host == "" {
+			return ValidationError{Field: "callback_uri", Message: "must be a http or https URL"}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"testing"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscriptions(t *testing.T) {
+	t.Run("should subscribe to the events of a record type", func(t *testing.T) {
+		t.Parallel()
+
+		server, request, body := newResourceServer(t, 201, `{"data":{"id":"b8a5c5a2-3e4f-4d6b-9c7a-1e2f3a4b5c6d","type":"subscriptions","attributes":{"callback_transport":"http","callback_uri":"https://example.com/events","event_type":"created","record_type":"accounts"}}}`)
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+
+		subscription, response, error := client.Subscriptions.Subscribe(form3.SubscriptionRequest{
+			OrganisationID:    parentOrganisationID,
+			CallbackURI:       "https://example.com/events",
+			CallbackTransport: form3.CallbackTransportHttp,
+			RecordType:        form3.RecordTypeAccounts,
+			EventType:         form3.EventTypeCreated,
+		})
+
+		assert.Nil(t, error)
+		assert.Equal(t, 201, response.StatusCode)
+		assert.Equal(t, "POST", request.Method)
+		assert.Equal(t, "/v1/notification/subscriptions", request.URL.Path)
+		assert.Contains(t, string(*body), `"attributes":{"callback_transport":"http","callback_uri":"https://example.com/events","deactivated":false,"event_type":"created","record_type":"accounts"}`)
+		assert.Contains(t, string(*body), `"organisation_id":"`+parentOrganisationID+`","type":"subscriptions"`)
+		assert.Equal(t, form3.RecordTypeAccounts, subscription.Data.Attributes.RecordType)
+	})
+
+	t.Run("should not subscribe with invalid details", func(t *testing.T) {
+		t.Parallel()
+
+		valid := form3.SubscriptionRequest{
+			OrganisationID:    parentOrganisationID,
+			CallbackURI:       "https://example.com/events",
+			CallbackTransport: form3.CallbackTransportHttp,
+			RecordType:        form3.RecordTypePayments,
+			EventType:         form3.EventTypeUpdated,
+		}
+
+		tests := []struct {
+			name     string
+			change   func(request *form3.SubscriptionRequest)
+			expected form3.ValidationError
+		}{
+			{"record type", func(r *form3.SubscriptionRequest) { r.RecordType = "" }, form3.ValidationError{Field: "record_type", Message: "is required"}},
+			{"event type", func(r *form3.SubscriptionRequest) { r.EventType = "" }, form3.ValidationError{Field: "event_type", Message: "is required"}},
+			{"callback uri", func(r *form3.SubscriptionRequest) { r.CallbackURI = "" }, form3.ValidationError{Field: "callback_uri", Message: "is required"}},
+			{"transport", func(r *form3.SubscriptionRequest) { r.CallbackTransport = "email" }, form3.ValidationError{Field: "callback_transport", Message: "must be http or queue"}},
+			{"http url", func(r *form3.SubscriptionRequest) { r.CallbackURI = "example.com/events" }, form3.ValidationError{Field: "callback_uri", Message: "must be a http or https URL"}},
+		}
+
+		client, _ := form3.New()
+
+		for _, test := range tests {
+			request := valid
+			test.change(&request)
+
+			subscription, response, error := client.Subscriptions.Subscribe(request)
+
+			assert.Nil(t, subscription, test.name)
+			assert.Nil(t, response, test.name)
+			assert.Equal(t, test.expected, error, test.name)
+		}
+	})
+
+	t.Run("should create, fetch, list, update and delete subscriptions", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		client, _ := form3.New(form3.WithBaseUrl(server.URL()))
+
+		queue, _, error := client.Subscriptions.Subscribe(form3.SubscriptionRequest{
+			OrganisationID:    parentOrganisationID,
+			CallbackURI:       "arn:aws:sqs:eu-west-1:000000000000:events",
+			CallbackTransport: form3.CallbackTransportQueue,
+			RecordType:        form3.RecordTypePaymentSubmissions,
+			EventType:         form3.EventTypeUpdated,
+		})
+
+		assert.Nil(t, error)
+
+		fetched, _, error := client.Subscriptions.Fetch(queue.Data.ID)
+
+		assert.Nil(t, error)
+		assert.Equal(t, queue.Data, fetched.Data)
+
+		fetched.Data.Attributes.Deactivated = true
+		updated, _, error := client.Subscriptions.Update(fetched)
+
+		assert.Nil(t, error)
+		assert.True(t, updated.Data.Attributes.Deactivated)
+		assert.Equal(t, int64(1), updated.Data.Version)
+
+		updated.Data.Attributes.Deactivated = false
+		updated, _, error = client.Subscriptions.Update(updated)
+
+		assert.Nil(t, error)
+		assert.False(t, updated.Data.Attributes.Deactivated)
+		assert.Equal(t, int64(2), updated.Data.Version)
+
+		subscriptions, _, error := client.Subscriptions.List(form3.ListOptions{Filter: map[string]string{"record_type": "payment_submissions"}})
+
+		assert.Nil(t, error)
+		assert.Len(t, subscriptions.Data, 1)
+
+		response, error := client.Subscriptions.Delete(updated.Data.ID, updated.Data.Version)
+
+		assert.Nil(t, error)
+		assert.Equal(t, 204, response.StatusCode)
+	})
+}
@@ -0,0 +1,22 @@
+package form3
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// newUuid generates a random (version 4) UUID.
+func newUuid() (string, error) {
+	uuid := make([]byte, 16)
+
+	_, error := rand.Read(uuid)
+
+	if error != nil {
+		return "", error
+	}
+
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16]), nil
+}
@@ -0,0 +1,100 @@
+package form3
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultWaitInterval          = 1 * time.Second  // DefaultWaitInterval is the default time between the first fetches of a resource being waited for.
+	DefaultWaitMaxInterval       = 30 * time.Second // DefaultWaitMaxInterval is the default maximum time between fetches of a resource being waited for.
+	DefaultWaitBackoffMultiplier = 2                // DefaultWaitBackoffMultiplier is the default growth of the time between fetches of a resource being waited for.
+)
+
+// WaitOptions allows one to customize how a resource is waited for.
+type WaitOptions struct {
+	Interval          time.Duration // Time between the first fetches, DefaultWaitInterval if not positive.
+	MaxInterval       time.Duration // Maximum time between fetches, DefaultWaitMaxInterval if not positive.
+	BackoffMultiplier float64       // How much the time between fetches grows after every fetch, DefaultWaitBackoffMultiplier if not positive and at least 1. Use 1 for a constant interval.
+	FinalStatuses     []string      // Statuses after which the resource does not change anymore. WaitForStatus only stops at the targets if not set, while AccountService.WaitForStatus uses the final account statuses.
+}
+
+// StatusOf defines the function interface that is used to know the status of a resource, empty if unknown.
+type StatusOf[T any] func(data *T) string
+
+// WaitForStatus fetches a resource until its status is one of the targets or the context is done.
+//
+// The time between fetches starts at the interval and grows with the back-off multiplier, up to the maximum interval.
+// If the resource reaches a final status that is not a target an error is returned, since it will not change anymore.
+// The last resource fetched successfully is returned together with the error if a fetch fails, the context is done
+// or a final status is reached.
+//
+// It is a function instead of a method since methods cannot have their own type parameters.
+// The time between fetches is measured with the client clock.
+func WaitForStatus[T ResourceData](ctx context.Context, service *ResourceService[T], id string, status StatusOf[T], targets []string, options WaitOptions) (*Resource[T], *ResponseMeta, error) {
+	interval, maxInterval, multiplier := options.Interval, options.MaxInterval, options.BackoffMultiplier
+
+	if interval <= 0 {
+		interval = DefaultWaitInterval
+	}
+
+	if maxInterval <= 0 {
+		maxInterval = DefaultWaitMaxInterval
+	}
+
+	if multiplier <= 0 {
+		multiplier = DefaultWaitBackoffMultiplier
+	}
+
+	// A multiplier below 1 would shrink the interval until the API is fetched without waiting
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	var last *Resource[T]
+
+	for {
+		resource, response, error := service.FetchWithContext(ctx, id)
+
+		if error != nil {
+			return last, response, error
+		}
+
+		last = resource
+
+		if resource.Data != nil {
+			current := status(resource.Data)
+
+			if containsStatus(targets, current) {
+				return resource, response, nil
+			}
+
+			if containsStatus(options.FinalStatuses, current) {
+				return resource, response, OperationError{Message: fmt.Sprintf("%s %s reached the final status %s", service.name, id, current)}
+			}
+		}
+
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			return resource, response, OperationError{Message: ctx.Err().Error()}
+		case <-service.Client.clock.After(interval):
+		}
+
+		interval = time.Duration(float64(interval) * multiplier)
+	}
+}
+
+func containsStatus(statuses []string, status string) bool {
+	for _, candidate := range statuses {
+		if candidate == status {
+			return true
+		}
+	}
+
+	return false
+}
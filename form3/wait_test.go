@@ -0,0 +1,140 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
+	"github.com/stretchr/testify/assert"
+)
+
+// newStatusServer replies to every fetch with an account in the next status, repeating the last one once they are all used.
+func newStatusServer(t *testing.T, statuses ...string) *httptest.Server {
+	return newSequenceServer(t, `{"data":{"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc","type":"accounts","attributes":{"status":"%s"}}}`, statuses...)
+}
+
+// newSequenceServer replies to every fetch with the body filled with the next status, repeating the last one once they are all used.
+func newSequenceServer(t *testing.T, body string, statuses ...string) *httptest.Server {
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[len(statuses)-1]
+
+		if fetches < len(statuses) {
+			status = statuses[fetches]
+		}
+
+		fetches++
+		fmt.Fprintf(w, body, status)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestWaitForStatus(t *testing.T) {
+	t.Run("should wait with back-off until an account is confirmed", func(t *testing.T) {
+		t.Parallel()
+
+		server := newStatusServer(t, "pending", "pending", "pending", "pending", "pending", "confirmed")
+		clock := form3test.NewFakeClock(time.Now()).WithAutoAdvance()
+		client := newTestClient(t, server.URL, form3.WithClock(clock))
+
+		account, response, error := client.Accounts.WaitForStatus(context.Background(), "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", []string{form3.AccountStatusConfirmed}, form3.WaitOptions{MaxInterval: 5 * time.Second})
+
+		assert.Nil(t, error)
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, form3.AccountStatusConfirmed, account.Data.Attributes.Status)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}, clock.Waits())
+	})
+
+	t.Run("should stop once an account reaches a final status that is not a target", func(t *testing.T) {
+		t.Parallel()
+
+		server := newStatusServer(t, "pending", "failed")
+		clock := form3test.NewFakeClock(time.Now()).WithAutoAdvance()
+		client := newTestClient(t, server.URL, form3.WithClock(clock))
+
+		account, _, error := client.Accounts.WaitForStatus(context.Background(), "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", []string{form3.AccountStatusConfirmed}, form3.WaitOptions{Interval: 500 * time.Millisecond, BackoffMultiplier: 3})
+
+		assert.Equal(t, form3.OperationError{Message: "account ad27e265-9605-4b4b-a0e5-3003ea9cc4dc reached the final status failed"}, error)
+		assert.Equal(t, form3.AccountStatusFailed, account.Data.Attributes.Status)
+		assert.Equal(t, []time.Duration{500 * time.Millisecond}, clock.Waits())
+	})
+
+	t.Run("should return the last account when the context is done before the target is reached", func(t *testing.T) {
+		t.Parallel()
+
+		server := newStatusServer(t, "pending")
+		client := newTestClient(t, server.URL, form3.WithClock(form3test.NewFakeClock(time.Now())))
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		account, _, error := client.Accounts.WaitForStatus(ctx, "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", []string{form3.AccountStatusConfirmed}, form3.WaitOptions{})
+
+		assert.Equal(t, form3.OperationError{Message: "context deadline exceeded"}, error)
+		assert.Equal(t, form3.AccountStatusPending, account.Data.Attributes.Status)
+	})
+
+	t.Run("should wait for the status of any resource", func(t *testing.T) {
+		t.Parallel()
+
+		server := newSequenceServer(t, `{"data":{"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc","colour":"%s"}}`, "red", "green")
+		clock := form3test.NewFakeClock(time.Now()).WithAutoAdvance()
+		client := newTestClient(t, server.URL, form3.WithClock(clock))
+		widgets := form3.NewResourceService[widgetData](client, "/v1/widgets", "widget")
+		colour := func(data *widgetData) string { return data.Colour }
+
+		widget, _, error := form3.WaitForStatus(context.Background(), widgets, "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", colour, []string{"green"}, form3.WaitOptions{BackoffMultiplier: 1})
+
+		assert.Nil(t, error)
+		assert.Equal(t, "green", widget.Data.Colour)
+		assert.Equal(t, []time.Duration{form3.DefaultWaitInterval}, clock.Waits())
+	})
+
+	t.Run("should not shrink the time between fetches below the interval", func(t *testing.T) {
+		t.Parallel()
+
+		server := newStatusServer(t, "pending", "pending", "confirmed")
+		clock := form3test.NewFakeClock(time.Now()).WithAutoAdvance()
+		client := newTestClient(t, server.URL, form3.WithClock(clock))
+
+		_, _, error := client.Accounts.WaitForStatus(context.Background(), "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", []string{form3.AccountStatusConfirmed}, form3.WaitOptions{BackoffMultiplier: 0.5})
+
+		assert.Nil(t, error)
+		assert.Equal(t, []time.Duration{time.Second, time.Second}, clock.Waits())
+	})
+
+	t.Run("should return the last account fetched when a fetch fails", func(t *testing.T) {
+		t.Parallel()
+
+		fetches := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fetches++
+
+			if fetches > 1 {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error_message":"record does not exist"}`))
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"data":{"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc","type":"accounts","attributes":{"status":"pending"}}}`))
+		}))
+		t.Cleanup(server.Close)
+		client := newTestClient(t, server.URL, form3.WithClock(form3test.NewFakeClock(time.Now()).WithAutoAdvance()))
+
+		account, response, error := client.Accounts.WaitForStatus(context.Background(), "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", []string{form3.AccountStatusConfirmed}, form3.WaitOptions{})
+
+		assert.NotNil(t, error)
+		assert.Equal(t, 404, response.StatusCode)
+		assert.Equal(t, form3.AccountStatusPending, account.Data.Attributes.Status)
+	})
+}
@@ -0,0 +1,370 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultWatchInterval    = 10 * time.Second // DefaultWatchInterval is the default time between the polls of a watcher.
+	DefaultWatchPageSize    = 100              // DefaultWatchPageSize is the default number of accounts listed per page by a watcher.
+	DefaultWatchMaxAccounts = 10000            // DefaultWatchMaxAccounts is the default number of accounts a watcher can watch.
+)
+
+// WatchOptions allows one to customize how accounts are watched.
+type WatchOptions struct {
+	Interval    time.Duration     // Time between polls, DefaultWatchInterval if not positive.
+	PageSize    int               // How many accounts are listed per page, DefaultWatchPageSize if not positive.
+	Filter      map[string]string // Only accounts whose attributes have the given values are watched.
+	Cursor      *WatchCursor      // Where to resume watching from, every existing account is reported as created if not set.
+	MaxAccounts int               // How many accounts can be watched, DefaultWatchMaxAccounts if not positive.
+}
+
+// WatchCursor is what a watcher knows about the accounts, so that watching can be resumed without missing changes.
+//
+// It is a snapshot of every watched account, since the previous data of an account is needed to know which attributes
+// changed and the API does not report deleted accounts. Its size grows with the number of watched accounts, which is
+// limited by WatchOptions.MaxAccounts. It can be marshalled to json to be stored.
+type WatchCursor struct {
+	Accounts map[string]AccountData `json:"accounts"` // Accounts as they were last reported, by account ID.
+}
+
+// AttributeDiff is a change of a single account attribute.
+type AttributeDiff struct {
+	Field  string // Name of the attribute, as in json, for example "bank_id".
+	Before any    // Value before the change.
+	After  any    // Value after the change.
+}
+
+// AccountWatchEvent is a change of an account found by a watcher.
+type AccountWatchEvent struct {
+	Type   EventType       // EventTypeCreated, EventTypeUpdated or EventTypeDeleted.
+	ID     string          // ID of the account.
+	Before *AccountData    // Account before the change, nil if it was created.
+	After  *AccountData    // Account after the change, nil if it was deleted.
+	Diffs  []AttributeDiff // Attributes that changed, in the order they are declared.
+}
+
+// Watcher periodically lists accounts and reports the accounts created, updated and deleted since the last poll.
+//
+// An account is updated when its version or any of its data changed. Accounts that are not listed anymore,
+// including the ones that stopped matching the filter, are deleted. Since accounts can move between pages while
+// they are listed, an account that is not listed is fetched to make sure it is gone before being deleted.
+//
+// Every poll lists all watched accounts, so a filter should be used to keep them below WatchOptions.MaxAccounts.
+//
+// The cursor only advances once an event is received, so that watching can be resumed from it after stopping.
+// It is safe to be used by multiple goroutines.
+type Watcher struct {
+	mutex    sync.Mutex
+	accounts *AccountService
+	options  WatchOptions
+	known    map[string]AccountData // Accounts as they were last reported, by account ID.
+	error    error                  // Why watching stopped, if it failed.
+}
+
+// NewWatcher creates a watcher of the accounts of an account service.
+func NewWatcher(accounts *AccountService, options WatchOptions) *Watcher {
+	if options.Interval <= 0 {
+		options.Interval = DefaultWatchInterval
+	}
+
+	if options.PageSize <= 0 {
+		options.PageSize = DefaultWatchPageSize
+	}
+
+	if options.MaxAccounts <= 0 {
+		options.MaxAccounts = DefaultWatchMaxAccounts
+	}
+
+	known := map[string]AccountData{}
+
+	if options.Cursor != nil {
+		for id, account := range options.Cursor.Accounts {
+			known[id] = copyAccountData(account)
+		}
+	}
+
+	return &Watcher{accounts: accounts, options: options, known: known}
+}
+
+// Watch starts polling the accounts, delivering their changes over the returned channel.
+//
+// The first poll is made right away. The channel is closed once the context is done or a poll fails,
+// in which case the error is available in Err.
+func (w *Watcher) Watch(ctx context.Context) <-chan AccountWatchEvent {
+	events := make(chan AccountWatchEvent)
+
+	go func() {
+		defer close(events)
+
+		for {
+			error := w.poll(ctx, events)
+
+			if error != nil {
+				if ctx.Err() == nil {
+					w.mutex.Lock()
+					w.error = error
+					w.mutex.Unlock()
+				}
+
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.accounts.Client.clock.After(w.options.Interval):
+			}
+		}
+	}()
+
+	return events
+}
+
+// Err returns why watching stopped, nil if it was stopped by its context.
+func (w *Watcher) Err() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.error
+}
+
+// Cursor returns a copy of the cursor, including every event received so far.
+func (w *Watcher) Cursor() *WatchCursor {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	cursor := &WatchCursor{Accounts: make(map[string]AccountData, len(w.known))}
+
+	for id, account := range w.known {
+		cursor.Accounts[id] = copyAccountData(account)
+	}
+
+	return cursor
+}
+
+// poll lists every account and delivers the changes since the last poll.
+func (w *Watcher) poll(ctx context.Context, events chan<- AccountWatchEvent) error {
+	accounts, _, error := listAll(ctx, w.accounts.ListWithContext, ListOptions{PageSize: w.options.PageSize, Filter: w.options.Filter})
+
+	if error != nil {
+		return error
+	}
+
+	accounts, error = w.confirmMissing(ctx, accounts)
+
+	if error != nil {
+		return error
+	}
+
+	error = w.checkMaxAccounts(accounts)
+
+	if error != nil {
+		return error
+	}
+
+	w.mutex.Lock()
+	changes := diffAccounts(w.known, accounts)
+	w.mutex.Unlock()
+
+	for _, change := range changes {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case events <- change:
+		}
+
+		w.mutex.Lock()
+
+		if change.After == nil {
+			delete(w.known, change.ID)
+		} else {
+			w.known[change.ID] = copyAccountData(*change.After)
+		}
+
+		w.mutex.Unlock()
+	}
+
+	return nil
+}
+
+// confirmMissing fetches the known accounts that were not listed, adding the ones that still exist and match the filter.
+//
+// Accounts listed more than once, because they moved between pages, are only kept once with their latest data.
+func (w *Watcher) confirmMissing(ctx context.Context, accounts []AccountData) ([]AccountData, error) {
+	listed := map[string]int{}
+	unique := []AccountData{}
+
+	for _, account := range accounts {
+		if index, ok := listed[account.ID]; ok {
+			unique[index] = account
+
+			continue
+		}
+
+		listed[account.ID] = len(unique)
+		unique = append(unique, account)
+	}
+
+	// Too many accounts are listed already, so there is no need to fetch the missing ones
+	error := w.checkMaxAccounts(unique)
+
+	if error != nil {
+		return nil, error
+	}
+
+	w.mutex.Lock()
+	missing := []string{}
+
+	for id := range w.known {
+		if _, ok := listed[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	w.mutex.Unlock()
+	sort.Strings(missing)
+
+	for _, id := range missing {
+		account, response, error := w.accounts.FetchWithContext(ctx, id)
+
+		if error != nil {
+			if response != nil && response.StatusCode == http.StatusNotFound {
+				continue
+			}
+
+			return nil, error
+		}
+
+		if account.Data != nil && matchesFilter(*account.Data, w.options.Filter) {
+			unique = append(unique, *account.Data)
+		}
+	}
+
+	return unique, nil
+}
+
+// checkMaxAccounts returns an error if there are more accounts than the watcher can watch.
+func (w *Watcher) checkMaxAccounts(accounts []AccountData) error {
+	if len(accounts) > w.options.MaxAccounts {
+		return OperationError{Message: fmt.Sprintf("%d accounts are listed but at most %d can be watched", len(accounts), w.options.MaxAccounts)}
+	}
+
+	return nil
+}
+
+// copyAccountData copies an account including its attributes, so that changing one of them does not change the other.
+func copyAccountData(account AccountData) AccountData {
+	if account.Attributes != nil {
+		attributes := *account.Attributes
+
+		if attributes.AlternativeNames != nil {
+			attributes.AlternativeNames = append([]string{}, attributes.AlternativeNames...)
+		}
+
+		if attributes.Name != nil {
+			attributes.Name = append([]string{}, attributes.Name...)
+		}
+
+		account.Attributes = &attributes
+	}
+
+	return account
+}
+
+// matchesFilter returns if an account has the values of every filter, which apply to its attributes or to its own fields.
+func matchesFilter(account AccountData, filter map[string]string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+
+	encoded, _ := json.Marshal(account)
+	data := map[string]any{}
+	_ = json.Unmarshal(encoded, &data)
+	attributes, _ := data["attributes"].(map[string]any)
+
+	for name, expected := range filter {
+		value, ok := attributes[name]
+
+		if !ok {
+			value = data[name]
+		}
+
+		if fmt.Sprint(value) != expected {
+			return false
+		}
+	}
+
+	return true
+}
+
+// diffAccounts finds the accounts created and updated, in the order they are listed, followed by the accounts deleted.
+func diffAccounts(known map[string]AccountData, accounts []AccountData) []AccountWatchEvent {
+	changes := []AccountWatchEvent{}
+	listed := map[string]bool{}
+
+	for index := range accounts {
+		after := copyAccountData(accounts[index])
+		listed[after.ID] = true
+		before, exists := known[after.ID]
+		before = copyAccountData(before)
+
+		switch {
+		case !exists:
+			changes = append(changes, AccountWatchEvent{Type: EventTypeCreated, ID: after.ID, After: &after, Diffs: diffAccountAttributes(nil, after.Attributes)})
+		case before.Version != after.Version || !reflect.DeepEqual(before, after):
+			changes = append(changes, AccountWatchEvent{Type: EventTypeUpdated, ID: after.ID, Before: &before, After: &after, Diffs: diffAccountAttributes(before.Attributes, after.Attributes)})
+		}
+	}
+
+	deleted := []string{}
+
+	for id := range known {
+		if !listed[id] {
+			deleted = append(deleted, id)
+		}
+	}
+
+	sort.Strings(deleted)
+
+	for _, id := range deleted {
+		before := copyAccountData(known[id])
+		changes = append(changes, AccountWatchEvent{Type: EventTypeDeleted, ID: id, Before: &before, Diffs: diffAccountAttributes(before.Attributes, nil)})
+	}
+
+	return changes
+}
+
+// diffAccountAttributes compares every attribute of two accounts, a missing account having no attributes set.
+func diffAccountAttributes(before *AccountAttributes, after *AccountAttributes) []AttributeDiff {
+	if before == nil {
+		before = &AccountAttributes{}
+	}
+
+	if after == nil {
+		after = &AccountAttributes{}
+	}
+
+	diffs := []AttributeDiff{}
+	beforeValue, afterValue := reflect.ValueOf(*before), reflect.ValueOf(*after)
+
+	for index := 0; index < beforeValue.NumField(); index++ {
+		previous, current := beforeValue.Field(index).Interface(), afterValue.Field(index).Interface()
+
+		if !reflect.DeepEqual(previous, current) {
+			field := beforeValue.Type().Field(index)
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			diffs = append(diffs, AttributeDiff{Field: name, Before: previous, After: current})
+		}
+	}
+
+	return diffs
+}
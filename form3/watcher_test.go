@@ -0,0 +1,342 @@
+//go:build unit
+
+package form3_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/castanhojfc/form3-client-go/form3"
+	"github.com/castanhojfc/form3-client-go/form3/form3test"
+	"github.com/stretchr/testify/assert"
+)
+
+func newWatchedAccount(id string, bankId string) *form3.Account {
+	return &form3.Account{
+		Data: &form3.AccountData{
+			ID:             id,
+			OrganisationID: parentOrganisationID,
+			Type:           "accounts",
+			Attributes:     &form3.AccountAttributes{Country: "GB", BankID: bankId, Name: []string{"Samantha Holder"}},
+		},
+	}
+}
+
+// receiveEvents receives a number of events, failing if they are not delivered in time.
+func receiveEvents(t *testing.T, events <-chan form3.AccountWatchEvent, count int) []form3.AccountWatchEvent {
+	received := []form3.AccountWatchEvent{}
+
+	for len(received) < count {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatalf("events were closed after %d of %d events", len(received), count)
+			}
+
+			received = append(received, event)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("only %d of %d events were received", len(received), count)
+		}
+	}
+
+	return received
+}
+
+// awaitPoll waits until the watcher is done polling and waits for the next poll.
+func awaitPoll(t *testing.T, clock *form3test.FakeClock) {
+	assert.Eventually(t, func() bool { return clock.Waiters() == 1 }, 5*time.Second, time.Millisecond)
+}
+
+// nextPoll releases the watcher once it is waiting for the next poll.
+func nextPoll(t *testing.T, clock *form3test.FakeClock, interval time.Duration) {
+	awaitPoll(t, clock)
+	clock.Advance(interval)
+}
+
+func TestWatcher(t *testing.T) {
+	t.Run("should report accounts created, updated and deleted", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		clock := form3test.NewFakeClock(time.Now())
+		client := newTestClient(t, server.URL().String(), form3.WithClock(clock), form3.WithHttpRetryAttempts(0))
+
+		client.Accounts.Create(newWatchedAccount("a1", "400300"))
+		client.Accounts.Create(newWatchedAccount("a2", "400301"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		watcher := form3.NewWatcher(client.Accounts, form3.WatchOptions{Interval: time.Minute, PageSize: 1})
+		events := watcher.Watch(ctx)
+
+		created := receiveEvents(t, events, 2)
+
+		assert.Equal(t, form3.EventTypeCreated, created[0].Type)
+		assert.Equal(t, "a1", created[0].ID)
+		assert.Nil(t, created[0].Before)
+		assert.Equal(t, "400300", created[0].After.Attributes.BankID)
+		assert.Contains(t, created[0].Diffs, form3.AttributeDiff{Field: "bank_id", Before: "", After: "400300"})
+		assert.Equal(t, "a2", created[1].ID)
+
+		server.SetAttribute("/v1/organisation/accounts/a1", "bank_id", "400302")
+		client.Accounts.Delete("a2", 0)
+		client.Accounts.Create(newWatchedAccount("a3", "400303"))
+		nextPoll(t, clock, time.Minute)
+
+		changes := receiveEvents(t, events, 3)
+
+		assert.Equal(t, form3.EventTypeUpdated, changes[0].Type)
+		assert.Equal(t, "a1", changes[0].ID)
+		assert.Equal(t, []form3.AttributeDiff{{Field: "bank_id", Before: "400300", After: "400302"}}, changes[0].Diffs)
+		assert.Equal(t, form3.EventTypeCreated, changes[1].Type)
+		assert.Equal(t, "a3", changes[1].ID)
+		assert.Equal(t, form3.EventTypeDeleted, changes[2].Type)
+		assert.Equal(t, "a2", changes[2].ID)
+		assert.Nil(t, changes[2].After)
+		assert.Equal(t, "400301", changes[2].Before.Attributes.BankID)
+		assert.Equal(t, time.Minute, clock.Waits()[0])
+	})
+
+	t.Run("should report nothing until an account changes", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		clock := form3test.NewFakeClock(time.Now())
+		client := newTestClient(t, server.URL().String(), form3.WithClock(clock), form3.WithHttpRetryAttempts(0))
+
+		client.Accounts.Create(newWatchedAccount("a1", "400300"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events := form3.NewWatcher(client.Accounts, form3.WatchOptions{}).Watch(ctx)
+
+		receiveEvents(t, events, 1)
+		nextPoll(t, clock, form3.DefaultWatchInterval)
+		awaitPoll(t, clock)
+		server.SetAttribute("/v1/organisation/accounts/a1", "status", "confirmed")
+		nextPoll(t, clock, form3.DefaultWatchInterval)
+
+		changes := receiveEvents(t, events, 1)
+
+		assert.Equal(t, []form3.AttributeDiff{{Field: "status", Before: "", After: "confirmed"}}, changes[0].Diffs)
+		assert.Equal(t, []time.Duration{form3.DefaultWatchInterval, form3.DefaultWatchInterval}, clock.Waits()[:2])
+	})
+
+	t.Run("should not share the accounts it knows with the events and the cursor", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		clock := form3test.NewFakeClock(time.Now())
+		client := newTestClient(t, server.URL().String(), form3.WithClock(clock), form3.WithHttpRetryAttempts(0))
+
+		client.Accounts.Create(newWatchedAccount("a1", "400300"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		watcher := form3.NewWatcher(client.Accounts, form3.WatchOptions{})
+		changes := receiveEvents(t, watcher.Watch(ctx), 1)
+		awaitPoll(t, clock)
+
+		changes[0].After.Attributes.BankID = "400301"
+		changes[0].After.Attributes.Name[0] = "Jane Holder"
+		watcher.Cursor().Accounts["a1"].Attributes.BankID = "400302"
+
+		attributes := watcher.Cursor().Accounts["a1"].Attributes
+
+		assert.Equal(t, "400300", attributes.BankID)
+		assert.Equal(t, []string{"Samantha Holder"}, attributes.Name)
+	})
+
+	t.Run("should resume from a cursor", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		clock := form3test.NewFakeClock(time.Now())
+		client := newTestClient(t, server.URL().String(), form3.WithClock(clock), form3.WithHttpRetryAttempts(0))
+
+		client.Accounts.Create(newWatchedAccount("a1", "400300"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		first := form3.NewWatcher(client.Accounts, form3.WatchOptions{})
+		receiveEvents(t, first.Watch(ctx), 1)
+		cancel()
+
+		stored, error := json.Marshal(first.Cursor())
+		assert.Nil(t, error)
+
+		cursor := &form3.WatchCursor{}
+		assert.Nil(t, json.Unmarshal(stored, cursor))
+
+		client.Accounts.Create(newWatchedAccount("a2", "400301"))
+
+		ctx, cancel = context.WithCancel(context.Background())
+		defer cancel()
+		second := form3.NewWatcher(client.Accounts, form3.WatchOptions{Cursor: cursor})
+		changes := receiveEvents(t, second.Watch(ctx), 1)
+
+		assert.Equal(t, form3.EventTypeCreated, changes[0].Type)
+		assert.Equal(t, "a2", changes[0].ID)
+		assert.Len(t, second.Cursor().Accounts, 2)
+	})
+
+	t.Run("should only watch the accounts matching the filter", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		clock := form3test.NewFakeClock(time.Now())
+		client := newTestClient(t, server.URL().String(), form3.WithClock(clock), form3.WithHttpRetryAttempts(0))
+
+		client.Accounts.Create(newWatchedAccount("a1", "400300"))
+		client.Accounts.Create(newWatchedAccount("a2", "400301"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		watcher := form3.NewWatcher(client.Accounts, form3.WatchOptions{Filter: map[string]string{"bank_id": "400301"}})
+		changes := receiveEvents(t, watcher.Watch(ctx), 1)
+
+		assert.Equal(t, "a2", changes[0].ID)
+		assert.Len(t, watcher.Cursor().Accounts, 1)
+	})
+
+	t.Run("should stop once listing fails", func(t *testing.T) {
+		t.Parallel()
+
+		server, _, _ := newResourceServer(t, 400, `{"error_message":"invalid filter"}`)
+		clock := form3test.NewFakeClock(time.Now())
+		client := newTestClient(t, server.URL, form3.WithClock(clock))
+
+		watcher := form3.NewWatcher(client.Accounts, form3.WatchOptions{})
+		events := watcher.Watch(context.Background())
+
+		_, open := <-events
+
+		assert.False(t, open)
+		assert.NotNil(t, watcher.Err())
+		assert.Empty(t, watcher.Cursor().Accounts)
+	})
+
+	t.Run("should stop without an error once the context is done", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		clock := form3test.NewFakeClock(time.Now())
+		client := newTestClient(t, server.URL().String(), form3.WithClock(clock), form3.WithHttpRetryAttempts(0))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		watcher := form3.NewWatcher(client.Accounts, form3.WatchOptions{})
+		events := watcher.Watch(ctx)
+
+		awaitPoll(t, clock)
+		cancel()
+
+		_, open := <-events
+
+		assert.False(t, open)
+		assert.Nil(t, watcher.Err())
+	})
+
+	t.Run("should only report an account that was not listed as deleted once it cannot be fetched", func(t *testing.T) {
+		t.Parallel()
+
+		lists, fetches := 0, 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			account := `{"id":"a1","type":"accounts","attributes":{"bank_id":"400300"}}`
+
+			if r.URL.Path == "/v1/organisation/accounts" {
+				lists++
+
+				if lists > 1 {
+					account = ""
+				}
+
+				fmt.Fprintf(w, `{"data":[%s],"links":{}}`, account)
+
+				return
+			}
+
+			fetches++
+
+			if fetches > 1 {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error_message":"record a1 does not exist"}`))
+
+				return
+			}
+
+			fmt.Fprintf(w, `{"data":%s}`, account)
+		}))
+		defer server.Close()
+		clock := form3test.NewFakeClock(time.Now())
+		client := newTestClient(t, server.URL, form3.WithClock(clock))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		watcher := form3.NewWatcher(client.Accounts, form3.WatchOptions{})
+		events := watcher.Watch(ctx)
+
+		receiveEvents(t, events, 1)
+		nextPoll(t, clock, form3.DefaultWatchInterval)
+		nextPoll(t, clock, form3.DefaultWatchInterval)
+
+		changes := receiveEvents(t, events, 1)
+
+		assert.Equal(t, form3.EventTypeDeleted, changes[0].Type)
+		assert.Equal(t, 3, lists)
+		assert.Equal(t, 2, fetches)
+	})
+
+	t.Run("should stop once more accounts than allowed are listed", func(t *testing.T) {
+		t.Parallel()
+
+		server := form3test.NewServer()
+		defer server.Close()
+		clock := form3test.NewFakeClock(time.Now())
+		client := newTestClient(t, server.URL().String(), form3.WithClock(clock), form3.WithHttpRetryAttempts(0))
+
+		client.Accounts.Create(newWatchedAccount("a1", "400300"))
+		client.Accounts.Create(newWatchedAccount("a2", "400301"))
+
+		watcher := form3.NewWatcher(client.Accounts, form3.WatchOptions{MaxAccounts: 1})
+		_, open := <-watcher.Watch(context.Background())
+
+		assert.False(t, open)
+		assert.Equal(t, form3.OperationError{Message: "2 accounts are listed but at most 1 can be watched"}, watcher.Err())
+	})
+
+	t.Run("should not fetch the accounts that were not listed when more accounts than allowed are listed", func(t *testing.T) {
+		t.Parallel()
+
+		fetches := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v1/organisation/accounts" {
+				_, _ = w.Write([]byte(`{"data":[{"id":"a1","type":"accounts"},{"id":"a2","type":"accounts"}],"links":{}}`))
+
+				return
+			}
+
+			fetches++
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+		client := newTestClient(t, server.URL, form3.WithHttpRetryAttempts(0))
+		cursor := &form3.WatchCursor{Accounts: map[string]form3.AccountData{"a0": {ID: "a0", Type: "accounts"}}}
+
+		watcher := form3.NewWatcher(client.Accounts, form3.WatchOptions{MaxAccounts: 1, Cursor: cursor})
+		_, open := <-watcher.Watch(context.Background())
+
+		assert.False(t, open)
+		assert.Equal(t, form3.OperationError{Message: "2 accounts are listed but at most 1 can be watched"}, watcher.Err())
+		assert.Equal(t, 0, fetches)
+	})
+}